@@ -7,7 +7,7 @@ import (
 	"testing"
 )
 
-type experiment struct {
+type matrixExperiment struct {
 	matrixType   matrix.MatrixType
 	initialValue float64
 	nDim         int
@@ -24,7 +24,7 @@ type experiment struct {
 func BenchmarkMatrixTypes(b *testing.B) {
 	// Interleaving of multiple threads may favor the TwoDimDividedMatrixType matrix to be divided in memory, as one thread's matrix allocation may be interleaved with
 	// another thread's activity which requires memory allocation too
-	experiments := []experiment{
+	experiments := []matrixExperiment{
 		{matrix.TwoDimDividedMatrixType, 0.5, 2048, 1000, 1.0e-4, 4},
 		{matrix.TwoDimContiguousMatrixType, 0.5, 2048, 1000, 1.0e-4, 4},
 		{matrix.OneDimMatrixType, 0.5, 2048, 1000, 1.0e-4, 4},
@@ -38,3 +38,35 @@ func BenchmarkMatrixTypes(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkPrecisions runs the simulation with single and double precision matrices to see the difference in terms of performance.
+// Single precision matrices halve the memory used, hence the memory bandwidth needed for each iteration.
+func BenchmarkPrecisions(b *testing.B) {
+	experiments := []matrixExperiment{
+		{matrix.TwoDimContiguousMatrixType, 0.5, 2048, 1000, 1.0e-4, 1},
+		{matrix.TwoDimContiguousMatrixType, 0.5, 2048, 1000, 1.0e-4, 4},
+		{matrix.OneDimMatrixType, 0.5, 2048, 1000, 1.0e-4, 1},
+		{matrix.OneDimMatrixType, 0.5, 2048, 1000, 1.0e-4, 4},
+	}
+
+	for _, params := range experiments {
+		solver := jacobi.NewSolver(
+			jacobi.WithThreads(params.nThreads),
+			jacobi.WithMatrixType(params.matrixType),
+			jacobi.WithMaxIters(params.maxIters),
+			jacobi.WithTolerance(params.tolerance),
+		)
+		problem := jacobi.NewProblem(params.initialValue, params.nDim)
+
+		b.Run(fmt.Sprintf("float32,%.4f,%d,%d,%.4f,%d,%s", params.initialValue, params.nDim, params.maxIters, params.tolerance, params.nThreads, params.matrixType.ToString()), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				jacobi.SolveOf[float32](solver, problem)
+			}
+		})
+		b.Run(fmt.Sprintf("float64,%.4f,%d,%d,%.4f,%d,%s", params.initialValue, params.nDim, params.maxIters, params.tolerance, params.nThreads, params.matrixType.ToString()), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				jacobi.SolveOf[float64](solver, problem)
+			}
+		})
+	}
+}
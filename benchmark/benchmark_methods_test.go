@@ -0,0 +1,150 @@
+package benchmark
+
+import (
+	"fmt"
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"testing"
+)
+
+type methodExperiment struct {
+	method    jacobi.Method
+	nDim      int
+	tolerance float64
+	nThreads  int
+}
+
+type preconditionerExperiment struct {
+	name           string
+	method         jacobi.Method
+	preconditioner jacobi.Preconditioner
+	nThreads       int
+}
+
+type multigridExperiment struct {
+	method   jacobi.Method
+	cycle    jacobi.Cycle
+	nDim     int
+	maxIters int
+	nThreads int
+}
+
+// BenchmarkMethods runs the simulation with different iterative methods to compare the time and the number of iterations
+// they take to converge, which is reported along with the relaxation factor used by the SOR methods.
+func BenchmarkMethods(b *testing.B) {
+	experiments := []methodExperiment{
+		{jacobi.JacobiMethod, 128, 1.0e-5, 1},
+		{jacobi.JacobiMethod, 128, 1.0e-5, 4},
+		{jacobi.GaussSeidelMethod, 128, 1.0e-5, 1},
+		{jacobi.RedBlackGaussSeidelMethod, 128, 1.0e-5, 4},
+		{jacobi.SORMethod, 128, 1.0e-5, 1},
+		{jacobi.RedBlackSORMethod, 128, 1.0e-5, 1},
+		{jacobi.RedBlackSORMethod, 128, 1.0e-5, 4},
+		{jacobi.ConjugateGradientMethod, 128, 1.0e-5, 1},
+		{jacobi.ConjugateGradientMethod, 128, 1.0e-5, 4},
+	}
+
+	for _, params := range experiments {
+		solver := jacobi.NewSolver(
+			jacobi.WithThreads(params.nThreads),
+			jacobi.WithMaxIters(1000000),
+			jacobi.WithTolerance(params.tolerance),
+			jacobi.WithMethod(params.method),
+		)
+		problem := jacobi.NewProblem(0.5, params.nDim)
+
+		b.Run(fmt.Sprintf("%s,%d,%.5f,%d", params.method.ToString(), params.nDim, params.tolerance, params.nThreads), func(b *testing.B) {
+			var res jacobi.Result
+			for i := 0; i < b.N; i++ {
+				res, _ = solver.Solve(problem)
+			}
+			b.ReportMetric(float64(res.Iterations), "iters")
+			b.ReportMetric(res.RelaxationFactor, "omega")
+		})
+	}
+}
+
+// BenchmarkMultigrid runs the multigrid and conjugate gradient methods against the jacobi method for big matrices, for which the jacobi method is far from converging
+// within the maximum number of iterations. The number of iterations and the maxDiff reached are reported.
+func BenchmarkMultigrid(b *testing.B) {
+	experiments := []multigridExperiment{
+		{jacobi.JacobiMethod, jacobi.VCycle, 256, 1000, 4},
+		{jacobi.MultigridMethod, jacobi.VCycle, 256, 1000, 1},
+		{jacobi.MultigridMethod, jacobi.WCycle, 256, 1000, 1},
+		{jacobi.ConjugateGradientMethod, jacobi.VCycle, 256, 1000, 4},
+		{jacobi.JacobiMethod, jacobi.VCycle, 1024, 1000, 4},
+		{jacobi.MultigridMethod, jacobi.VCycle, 1024, 1000, 1},
+		{jacobi.MultigridMethod, jacobi.WCycle, 1024, 1000, 1},
+		{jacobi.ConjugateGradientMethod, jacobi.VCycle, 1024, 1000, 4},
+		{jacobi.JacobiMethod, jacobi.VCycle, 4096, 1000, 4},
+		{jacobi.MultigridMethod, jacobi.VCycle, 4096, 1000, 1},
+		{jacobi.MultigridMethod, jacobi.WCycle, 4096, 1000, 1},
+		{jacobi.ConjugateGradientMethod, jacobi.VCycle, 4096, 1000, 4},
+	}
+
+	for _, params := range experiments {
+		solver := jacobi.NewSolver(
+			jacobi.WithThreads(params.nThreads),
+			jacobi.WithMaxIters(params.maxIters),
+			jacobi.WithTolerance(1.0e-4),
+			jacobi.WithMethod(params.method),
+			jacobi.WithCycle(params.cycle),
+		)
+		problem := jacobi.NewProblem(0.5, params.nDim)
+
+		name := params.method.ToString()
+		if params.method == jacobi.MultigridMethod {
+			name += "," + params.cycle.ToString()
+		}
+		b.Run(fmt.Sprintf("%s,%d,%d,%d", name, params.nDim, params.maxIters, params.nThreads), func(b *testing.B) {
+			var res jacobi.Result
+			for i := 0; i < b.N; i++ {
+				res, _ = solver.Solve(problem)
+			}
+			b.ReportMetric(float64(res.Iterations), "iters")
+			b.ReportMetric(res.MaxDiff, "maxdiff")
+		})
+	}
+}
+
+// BenchmarkPreconditioners runs the conjugate gradient method with different preconditioners against the jacobi method on the same problems
+// to compare their time-to-solution. The number of iterations is reported.
+func BenchmarkPreconditioners(b *testing.B) {
+	nDim := 64
+	problems := map[string]jacobi.Problem{"uniform": jacobi.NewProblem(0.5, nDim)}
+	source := jacobi.NewProblem(0.5, nDim)
+	source.Source = matrix.NewOneDimMatrix(0.01, nDim+2, 0.0, 0.0, 0.0, 0.0)
+	source.Boundaries.Top = matrix.LinearBoundary(0.0, 1.0)
+	source.Conditions.Right = jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+	source.Boundaries.Right = matrix.ConstantBoundary(0.0)
+	problems["source"] = source
+
+	experiments := []preconditionerExperiment{
+		{"none", jacobi.JacobiMethod, nil, 4},
+		{"none", jacobi.ConjugateGradientMethod, nil, 1},
+		{"none", jacobi.ConjugateGradientMethod, nil, 4},
+		{"diagonal", jacobi.ConjugateGradientMethod, jacobi.DiagonalPreconditioner{}, 1},
+		{"SSOR", jacobi.ConjugateGradientMethod, jacobi.SSORPreconditioner{RelaxationFactor: 1.5}, 1},
+		{"V-cycle", jacobi.ConjugateGradientMethod, jacobi.MultigridPreconditioner{}, 1},
+	}
+
+	for _, problemName := range []string{"uniform", "source"} {
+		for _, params := range experiments {
+			solver := jacobi.NewSolver(
+				jacobi.WithThreads(params.nThreads),
+				jacobi.WithMaxIters(1000000),
+				jacobi.WithTolerance(1.0e-5),
+				jacobi.WithMethod(params.method),
+				jacobi.WithPreconditioner(params.preconditioner),
+			)
+
+			b.Run(fmt.Sprintf("%s,%s,%s,%d", problemName, params.method.ToString(), params.name, params.nThreads), func(b *testing.B) {
+				var res jacobi.Result
+				for i := 0; i < b.N; i++ {
+					res, _ = solver.Solve(problems[problemName])
+				}
+				b.ReportMetric(float64(res.Iterations), "iters")
+			})
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package jacobi
+
+import (
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+)
+
+const (
+	// Dirichlet is the kind of an edge whose cells have fixed values
+	Dirichlet BoundaryKind = iota
+	// Neumann is the kind of an edge through which a fixed flux enters the simulated space.
+	// Its cells are updated from the adjacent inner cells on every iteration
+	Neumann
+	// Periodic is the kind of an edge which wraps around to the opposite edge, which must also be periodic.
+	// Its cells are a copy of the inner cells next to the opposite edge, hence its values are ignored
+	Periodic
+	// Robin is the kind of an edge which exchanges heat by convection with the ambient, e.g. a surface cooled by air.
+	// Its cells are updated from the adjacent inner cells on every iteration
+	Robin
+)
+
+// BoundaryKind defines how the cells of an edge are computed
+type BoundaryKind int
+
+// ToString returns a string representation of a boundary kind
+func (kind BoundaryKind) ToString() string {
+	switch kind {
+	case Neumann:
+		return "Neumann"
+	case Periodic:
+		return "Periodic"
+	case Robin:
+		return "Robin"
+	default:
+		return "Dirichlet"
+	}
+}
+
+// BoundaryCondition defines the condition of an edge of the simulated space
+// The zero value is a Dirichlet condition, whose values are the edge values in the problem boundaries
+type BoundaryCondition struct {
+	Kind BoundaryKind
+	// HeatTransferCoefficient is the convective heat transfer coefficient of a Robin edge
+	HeatTransferCoefficient float64
+}
+
+// BoundaryConditions defines the conditions of the four edges surrounding the simulated 2D space
+// The meaning of each edge values in the problem boundaries depends on its condition:
+// - Dirichlet: the fixed values of the edge cells
+// - Neumann: the flux entering the simulated space through the edge, zero for an insulated edge
+// - Periodic: ignored
+// - Robin: the ambient temperature
+type BoundaryConditions struct {
+	Top, Bottom, Left, Right BoundaryCondition
+}
+
+// validate checks periodic edges come in pairs of opposite edges and heat transfer coefficients aren't negative
+func (conditions BoundaryConditions) validate() error {
+	if (conditions.Top.Kind == Periodic) != (conditions.Bottom.Kind == Periodic) || (conditions.Left.Kind == Periodic) != (conditions.Right.Kind == Periodic) {
+		return ErrUnpairedPeriodicBoundary
+	}
+	for _, condition := range []BoundaryCondition{conditions.Top, conditions.Bottom, conditions.Left, conditions.Right} {
+		if condition.HeatTransferCoefficient < 0 {
+			return ErrNegativeHeatTransferCoefficient
+		}
+	}
+	return nil
+}
+
+// isDirichlet returns true if every edge has fixed values
+func (conditions BoundaryConditions) isDirichlet() bool {
+	return conditions.Top.Kind == Dirichlet && conditions.Bottom.Kind == Dirichlet && conditions.Left.Kind == Dirichlet && conditions.Right.Kind == Dirichlet
+}
+
+// isPeriodic returns true if any edge is periodic
+func (conditions BoundaryConditions) isPeriodic() bool {
+	return conditions.Top.Kind == Periodic || conditions.Left.Kind == Periodic
+}
+
+// isComputed returns true if the edge cells are computed from its adjacent inner cells
+func (condition BoundaryCondition) isComputed() bool {
+	return condition.Kind != Dirichlet && condition.Kind != Periodic
+}
+
+// ghostValue computes the value of a cell of the edge given the value of its source inner cell,
+// the edge value for that cell, the grid spacing and the conductivity between both cells
+// The source inner cell is the adjacent one, except for periodic edges, for which it's the one next to the opposite edge
+func (condition BoundaryCondition) ghostValue(inner, value, spacing, conductivity float64) float64 {
+	switch condition.Kind {
+	case Periodic:
+		return inner
+	case Neumann:
+		// The flux is approximated by the difference between both cells
+		return inner + spacing*value/conductivity
+	case Robin:
+		// The edge surface lies halfway between both cells, its temperature is approximated by their mean
+		// and the flux by their difference, which must match the heat exchanged with the ambient
+		biot := condition.HeatTransferCoefficient * spacing / conductivity
+		return ((1-biot/2)*inner + biot*value) / (1 + biot/2)
+	default:
+		return value
+	}
+}
+
+// updateGhostCells updates the cells surrounding the simulated space whose values depend on the inner cells
+func updateGhostCells[T matrix.Float](problem Problem, mat matrix.MatrixOf[T], st stencil[T]) {
+	if problem.Conditions.isDirichlet() {
+		return
+	}
+
+	rows, cols := problem.size()
+	rows, cols = rows+2, cols+2
+	spacing, boundaries, conditions := problem.spacing(), problem.Boundaries, problem.Conditions
+
+	// Source inner rows and columns of each edge
+	top, bottom, left, right := 1, rows-2, 1, cols-2
+	if conditions.Top.Kind == Periodic {
+		top, bottom = rows-2, 1
+	}
+	if conditions.Left.Kind == Periodic {
+		left, right = cols-2, 1
+	}
+
+	for j := 1; j < cols-1; j++ {
+		if conditions.Top.Kind != Dirichlet {
+			mat.SetCell(0, j, T(conditions.Top.ghostValue(float64(mat.GetCell(top, j)), boundaries.Top.Value(j, cols), spacing, st.faceConductivity(1, j, 0, j))))
+		}
+		if conditions.Bottom.Kind != Dirichlet {
+			mat.SetCell(rows-1, j, T(conditions.Bottom.ghostValue(float64(mat.GetCell(bottom, j)), boundaries.Bottom.Value(j, cols), spacing, st.faceConductivity(rows-2, j, rows-1, j))))
+		}
+	}
+	for i := 1; i < rows-1; i++ {
+		if conditions.Left.Kind != Dirichlet {
+			mat.SetCell(i, 0, T(conditions.Left.ghostValue(float64(mat.GetCell(i, left)), boundaries.Left.Value(i, rows), spacing, st.faceConductivity(i, 1, i, 0))))
+		}
+		if conditions.Right.Kind != Dirichlet {
+			mat.SetCell(i, cols-1, T(conditions.Right.ghostValue(float64(mat.GetCell(i, right)), boundaries.Right.Value(i, rows), spacing, st.faceConductivity(i, cols-2, i, cols-1))))
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package jacobi
+
+import (
+	"context"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+)
+
+// The conjugate gradient method solves the linear system A*u = b whose solution is the fixed point of the jacobi method,
+// where A is symmetric positive definite. Neither A nor b are built: the residual b - A*u of a cell is the difference between
+// its new value and its value times the weight of the stencil, and A is applied to the search direction in the same way
+// with the stencil of the correction problem, which has no heat source and homogeneous edges
+
+// runConjugateGradient runs the single-threaded or the multi-threaded version of the conjugate gradient method depending on the number of threads
+func runConjugateGradient[T matrix.Float](ctx context.Context, problem Problem, opts options) (matrix.MatrixOf[T], int, float64, error) {
+	if opts.nThreads == 1 {
+		return runSinglethreadedConjugateGradient[T](ctx, problem, opts)
+	}
+	return runWorkers(ctx, problem, opts, worker[T].solveConjugateGradientSubproblem)
+}
+
+// runSinglethreadedConjugateGradient runs the conjugate gradient method, whose matrices cells are of type T, preconditioned by the preconditioner
+// of the options if it's set
+// The maxDiff of each iteration is the one the jacobi method would get from the current solution, so both methods stop at the same tolerance
+// If the context is done, it stops at the end of the current iteration and the partial result is returned along with the context error
+func runSinglethreadedConjugateGradient[T matrix.Float](ctx context.Context, problem Problem, opts options) (matrix.MatrixOf[T], int, float64, error) {
+	rows, cols := problem.size()
+	correction := problem.correctionProblem(nil)
+
+	// Solution, residual, search direction and the result of applying A to it, whose edges are those of the correction problem
+	sol, st := newMatrix[T](problem, opts.matrixType), newStencil[T](problem)
+	res, dir, dirOp, dirSt := newMatrix[T](correction, opts.matrixType), newMatrix[T](correction, opts.matrixType), newMatrix[T](correction, opts.matrixType), newStencil[T](correction)
+	weights := newMatrix[T](correction, opts.matrixType)
+
+	// The preconditioned residual is the residual itself unless there's a preconditioner, which solves the correction problem
+	// of the residual divided by the weights, i.e. the difference between a jacobi sweep of the solution and the solution itself
+	precRes, precondition := res, func() {}
+	if opts.preconditioner != nil {
+		precRes = newMatrix[T](correction, opts.matrixType)
+		jacobiRes, precCorrection := newMatrix[float64](correction, opts.matrixType), newMatrix[float64](correction, opts.matrixType)
+		apply, err := opts.preconditioner.Setup(problem.correctionProblem(jacobiRes), jacobiRes)
+		if err != nil {
+			return nil, 0, 0.0, err
+		}
+
+		precondition = func() {
+			for i := 1; i <= rows; i++ {
+				for j := 1; j <= cols; j++ {
+					jacobiRes.SetCell(i, j, float64(res.GetCell(i, j)/weights.GetCell(i, j)))
+					precCorrection.SetCell(i, j, 0.0)
+				}
+			}
+			apply(precCorrection)
+			for i := 1; i <= rows; i++ {
+				for j := 1; j <= cols; j++ {
+					precRes.SetCell(i, j, T(precCorrection.GetCell(i, j)))
+				}
+			}
+		}
+	}
+
+	// The first search direction is the preconditioned residual of the initial solution
+	iterMaxDiff := T(0.0)
+	updateGhostCells(problem, sol, st)
+	for i := 1; i <= rows; i++ {
+		for j := 1; j <= cols; j++ {
+			diff := st.newValue(i, j, sol.GetCell(i, j), sol.GetCell(i-1, j), sol.GetCell(i+1, j), sol.GetCell(i, j-1), sol.GetCell(i, j+1)) - sol.GetCell(i, j)
+			w := st.weight(i, j)
+			weights.SetCell(i, j, w)
+			res.SetCell(i, j, w*diff)
+			iterMaxDiff = max(iterMaxDiff, utils.Abs(diff))
+		}
+	}
+	precondition()
+	resDotPrecRes := T(0.0)
+	for i := 1; i <= rows; i++ {
+		for j := 1; j <= cols; j++ {
+			dir.SetCell(i, j, precRes.GetCell(i, j))
+			resDotPrecRes += res.GetCell(i, j) * precRes.GetCell(i, j)
+		}
+	}
+
+	nIters, maxDiff := 0, float64(iterMaxDiff)
+	var err error
+
+	for maxDiff > opts.tolerance && nIters < opts.maxIters {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		dirDotOp := T(0.0)
+		updateGhostCells(correction, dir, dirSt)
+		for i := 1; i <= rows; i++ {
+			for j := 1; j <= cols; j++ {
+				value := weights.GetCell(i, j) * (dir.GetCell(i, j) - dirSt.newValue(i, j, dir.GetCell(i, j), dir.GetCell(i-1, j), dir.GetCell(i+1, j), dir.GetCell(i, j-1), dir.GetCell(i, j+1)))
+				dirOp.SetCell(i, j, value)
+				dirDotOp += dir.GetCell(i, j) * value
+			}
+		}
+
+		// Move the solution along the search direction to minimize the error
+		alpha := resDotPrecRes / dirDotOp
+		iterMaxDiff = 0.0
+		for i := 1; i <= rows; i++ {
+			for j := 1; j <= cols; j++ {
+				sol.SetCell(i, j, sol.GetCell(i, j)+alpha*dir.GetCell(i, j))
+				res.SetCell(i, j, res.GetCell(i, j)-alpha*dirOp.GetCell(i, j))
+				iterMaxDiff = max(iterMaxDiff, utils.Abs(res.GetCell(i, j)/weights.GetCell(i, j)))
+			}
+		}
+		precondition()
+
+		// The next search direction is the preconditioned residual made conjugate to the previous ones
+		// Its factor is the Polak-Ribiere one, which keeps converging even if the preconditioner isn't exactly symmetric, e.g. a multigrid cycle
+		newResDotPrecRes, opDotPrecRes := T(0.0), T(0.0)
+		for i := 1; i <= rows; i++ {
+			for j := 1; j <= cols; j++ {
+				newResDotPrecRes += res.GetCell(i, j) * precRes.GetCell(i, j)
+				opDotPrecRes += dirOp.GetCell(i, j) * precRes.GetCell(i, j)
+			}
+		}
+		beta := -alpha * opDotPrecRes / resDotPrecRes
+		for i := 1; i <= rows; i++ {
+			for j := 1; j <= cols; j++ {
+				dir.SetCell(i, j, precRes.GetCell(i, j)+beta*dir.GetCell(i, j))
+			}
+		}
+
+		resDotPrecRes, maxDiff = newResDotPrecRes, float64(iterMaxDiff)
+		nIters++
+		opts.notifyIteration(nIters, maxDiff)
+	}
+
+	// Leave the edges consistent with the resulting inner cells
+	updateGhostCells(problem, sol, st)
+
+	return sol, nIters, maxDiff, err
+}
+
+// Runs the conjugate gradient method for the worker subproblem to get its partial result
+// The search direction is shared with the adjacent workers before applying A to it, and the dot products and the maxDiff
+// are reduced among all workers, so that every worker moves along the same direction by the same step
+func (worker worker[T]) solveConjugateGradientSubproblem(ctx context.Context, resMat matrix.MatrixOf[T], problem Problem, problemSt stencil[T], opts options) (int, float64, bool) {
+	matDef, correction := worker.matDef, problem.correctionProblem(nil)
+	rows, cols := matDef.Rows, matDef.Cols
+	// Fields of the problem are decomposed like the matrix
+	x0, y0, st, dirSt := matDef.Coords.X0, matDef.Coords.Y0, problemSt.submatrix(matDef), newStencil[T](correction).submatrix(matDef)
+
+	sol, res, dir, dirOp, weights := resMat.Clone(matDef), resMat.Clone(matDef), resMat.Clone(matDef), resMat.Clone(matDef), resMat.Clone(matDef)
+
+	// The first search direction is the residual of the initial solution
+	resDotRes, iterMaxDiff := T(0.0), T(0.0)
+	worker.setupBoundaries(T(problem.InitialValue), problem.Boundaries)
+	worker.sendOuterCells(sol)
+	worker.recvAdjacentCells(sol)
+	worker.updateGhostCells(sol, problem, st)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			diff := st.newValue(x0+i, y0+j, sol.GetCell(i, j), worker.getCell(sol, i-1, j), worker.getCell(sol, i+1, j), worker.getCell(sol, i, j-1), worker.getCell(sol, i, j+1)) - sol.GetCell(i, j)
+			w := st.weight(x0+i, y0+j)
+			weights.SetCell(i, j, w)
+			res.SetCell(i, j, w*diff)
+			dir.SetCell(i, j, w*diff)
+			resDotRes += w * diff * w * diff
+			iterMaxDiff = max(iterMaxDiff, utils.Abs(diff))
+		}
+	}
+	resDotRes = worker.sumReduce(ctx, resDotRes).value
+	red := worker.maxReduce(ctx, iterMaxDiff)
+	nIters, maxDiff, stop := 0, float64(red.value), red.stop
+
+	// From now on, adjacent cells are the ones of the search direction
+	worker.setupBoundaries(0.0, correction.Boundaries)
+
+	for maxDiff > opts.tolerance && nIters < opts.maxIters && !stop {
+		dirDotOp := T(0.0)
+		worker.sendOuterCells(dir)
+		worker.recvAdjacentCells(dir)
+		worker.updateGhostCells(dir, correction, dirSt)
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				value := weights.GetCell(i, j) * (dir.GetCell(i, j) - dirSt.newValue(x0+i, y0+j, dir.GetCell(i, j), worker.getCell(dir, i-1, j), worker.getCell(dir, i+1, j), worker.getCell(dir, i, j-1), worker.getCell(dir, i, j+1)))
+				dirOp.SetCell(i, j, value)
+				dirDotOp += dir.GetCell(i, j) * value
+			}
+		}
+
+		// Move the solution along the search direction to minimize the error
+		alpha, newResDotRes := resDotRes/worker.sumReduce(ctx, dirDotOp).value, T(0.0)
+		iterMaxDiff = 0.0
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				sol.SetCell(i, j, sol.GetCell(i, j)+alpha*dir.GetCell(i, j))
+				res.SetCell(i, j, res.GetCell(i, j)-alpha*dirOp.GetCell(i, j))
+				newResDotRes += res.GetCell(i, j) * res.GetCell(i, j)
+				iterMaxDiff = max(iterMaxDiff, utils.Abs(res.GetCell(i, j)/weights.GetCell(i, j)))
+			}
+		}
+		newResDotRes = worker.sumReduce(ctx, newResDotRes).value
+		red = worker.maxReduce(ctx, iterMaxDiff)
+
+		// The next search direction is the residual made conjugate to the previous ones
+		beta := newResDotRes / resDotRes
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				dir.SetCell(i, j, res.GetCell(i, j)+beta*dir.GetCell(i, j))
+			}
+		}
+
+		resDotRes, maxDiff, stop = newResDotRes, float64(red.value), red.stop
+		nIters++
+
+		if worker.id == 0 {
+			opts.notifyIteration(nIters, maxDiff)
+		}
+	}
+
+	worker.mergeSubproblem(resMat, sol)
+
+	return nIters, maxDiff, stop
+}
@@ -0,0 +1,55 @@
+package jacobi
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNonPositiveSize is returned when the number of rows or columns of the problem isn't greater than zero
+	ErrNonPositiveSize = errors.New("jacobi: the number of rows and columns of the problem must be greater than zero")
+	// ErrNegativeSpacing is returned when the distance between two adjacent cells is negative
+	ErrNegativeSpacing = errors.New("jacobi: the spacing between cells can't be negative")
+	// ErrMissingBoundary is returned when any of the edges of the problem isn't defined
+	ErrMissingBoundary = errors.New("jacobi: every boundary of the problem must be defined")
+	// ErrBoundaryLength is returned when the explicit values of an edge don't match its length, which is the number of columns (top and bottom edges)
+	// or rows (left and right edges) of the problem plus the corners
+	ErrBoundaryLength = errors.New("jacobi: the number of values of a boundary must be the length of its edge plus two")
+	// ErrUnpairedPeriodicBoundary is returned when an edge is periodic but its opposite edge isn't
+	ErrUnpairedPeriodicBoundary = errors.New("jacobi: periodic boundaries must be defined on both opposite edges")
+	// ErrNegativeHeatTransferCoefficient is returned when the heat transfer coefficient of a Robin edge is negative
+	ErrNegativeHeatTransferCoefficient = errors.New("jacobi: the heat transfer coefficient of a boundary can't be negative")
+	// ErrMaskSize is returned when the mask size doesn't match the size of the problem matrix, which is the size of the problem plus two rows and columns
+	ErrMaskSize = errors.New("jacobi: the mask must have the size of the problem plus the boundaries")
+	// ErrInitialMatrixSize is returned when the initial matrix size doesn't match the size of the problem matrix, which is the size of the problem plus two rows and columns
+	ErrInitialMatrixSize = errors.New("jacobi: the initial matrix must have the size of the problem plus the boundaries")
+	// ErrSourceSize is returned when the heat source size doesn't match the size of the problem matrix, which is the size of the problem plus two rows and columns
+	ErrSourceSize = errors.New("jacobi: the heat source must have the size of the problem plus the boundaries")
+	// ErrConductivitySize is returned when the conductivity size doesn't match the size of the problem matrix, which is the size of the problem plus two rows and columns
+	ErrConductivitySize = errors.New("jacobi: the conductivity must have the size of the problem plus the boundaries")
+	// ErrNonPositiveConductivity is returned when the conductivity of any cell isn't greater than zero, void cells should be used instead
+	ErrNonPositiveConductivity = errors.New("jacobi: the conductivity of every cell must be greater than zero")
+	// ErrNonPositiveMaxIters is returned when the maximum number of iterations isn't greater than zero
+	ErrNonPositiveMaxIters = errors.New("jacobi: the maximum number of iterations must be greater than zero")
+	// ErrNonPositiveTolerance is returned when the tolerance isn't greater than zero
+	ErrNonPositiveTolerance = errors.New("jacobi: the tolerance must be greater than zero")
+	// ErrNonPositiveThreads is returned when the number of threads isn't greater than zero
+	ErrNonPositiveThreads = errors.New("jacobi: the number of threads must be greater than zero")
+	// ErrDampingFactorOutOfRange is returned when the damping factor isn't positive and lower than 1.25
+	ErrDampingFactorOutOfRange = errors.New("jacobi: the damping factor must be positive and lower than 1.25")
+	// ErrRelaxationFactorOutOfRange is returned when the relaxation factor isn't positive and lower than 2.5
+	ErrRelaxationFactorOutOfRange = errors.New("jacobi: the relaxation factor must be positive and lower than 2.5")
+	// ErrNegativeLevels is returned when the number of levels is negative
+	ErrNegativeLevels = errors.New("jacobi: the number of levels can't be negative")
+	// ErrTooManyLevels is returned when the rows and columns of the problem can't be halved as many times as the number of levels requires
+	ErrTooManyLevels = errors.New("jacobi: the size of the problem can't be halved as many times as the number of levels")
+	// ErrUnsupportedMultigridProblem is returned when the multigrid method is used with a mask or conductivity fields
+	ErrUnsupportedMultigridProblem = errors.New("jacobi: the multigrid method doesn't support masks nor conductivity fields")
+	// ErrUnknownMethod is returned when the method isn't any of the defined ones
+	ErrUnknownMethod = errors.New("jacobi: unknown method")
+	// ErrSequentialMethod is returned when a sequential method, e.g. GaussSeidelMethod or a preconditioned ConjugateGradientMethod, is used with more than one thread
+	ErrSequentialMethod = errors.New("jacobi: the method can only be run by a single thread")
+	// ErrThreadsNotPerfectSquare is returned when the multithreaded version is used with a number of threads which isn't a perfect square
+	ErrThreadsNotPerfectSquare = errors.New("jacobi: the number of threads must be a perfect square")
+	// ErrSizeNotDivisible is returned when the multithreaded version is used with a number of rows or columns which isn't divisible by the number of threads
+	ErrSizeNotDivisible = errors.New("jacobi: the number of rows and columns of the problem must be divisible by the number of threads")
+)
@@ -0,0 +1,50 @@
+package jacobi
+
+import (
+	"context"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"math"
+)
+
+// runGaussSeidel runs the Gauss-Seidel method, whose matrices cells are of type T
+// Unlike the jacobi method, cells are updated in place, so the top and left adjacent cells already have their new values
+// when a cell is computed. Hence, it's inherently sequential
+// It's also the SOR method, as the cells are relaxed by the relaxation factor of the options
+// If the context is done, it stops at the end of the current iteration and the partial result is returned along with the context error
+func runGaussSeidel[T matrix.Float](ctx context.Context, problem Problem, opts options) (matrix.MatrixOf[T], int, float64, error) {
+	rows, cols := problem.size()
+
+	// A single matrix is needed, as cells are updated in place
+	mat := newMatrix[T](problem, opts.matrixType)
+
+	nIters, maxDiff, st, omega := 0, math.MaxFloat64, newStencil[T](problem), T(opts.relaxationFactor)
+	var err error
+
+	for maxDiff > opts.tolerance && nIters < opts.maxIters {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		iterMaxDiff := T(0.0)
+		updateGhostCells(problem, mat, st)
+
+		for i := 1; i <= rows; i++ {
+			for j := 1; j <= cols; j++ {
+				// Compute new value with 3x3 filter with no corners
+				prevValue := mat.GetCell(i, j)
+				mat.SetCell(i, j, relax(prevValue, st.newValue(i, j, prevValue, mat.GetCell(i-1, j), mat.GetCell(i+1, j), mat.GetCell(i, j-1), mat.GetCell(i, j+1)), omega))
+				iterMaxDiff = max(iterMaxDiff, utils.Abs(prevValue-mat.GetCell(i, j)))
+			}
+		}
+
+		maxDiff = float64(iterMaxDiff)
+		nIters++
+		opts.notifyIteration(nIters, maxDiff)
+	}
+
+	// Leave the edges consistent with the resulting inner cells
+	updateGhostCells(problem, mat, st)
+
+	return mat, nIters, maxDiff, err
+}
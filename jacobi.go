@@ -1,13 +1,38 @@
 package jacobi
 
 import (
+	"context"
 	"github.com/mcanalesmayo/jacobi-go/model/matrix"
 )
 
 // RunJacobi runs the jacobi method to simulate the thermal transmission in a 2D space
-func RunJacobi(initialValue float64, nDim int, maxIters int, tolerance float64, nThreads int, matrixType matrix.MatrixType) (matrix.Matrix, int, float64) {
-	if nThreads == 1 {
-		return runSinglethreadedJacobi(initialValue, nDim, maxIters, tolerance, matrixType)
+// It's a shortcut for solving a problem with the default boundaries by using a Solver
+// Additional options, e.g. WithMethod for an alternate solver, are applied on top of the given parameters
+// It panics if the parameters are invalid, use Solver.Solve to get an error instead
+func RunJacobi(initialValue float64, nDim int, maxIters int, tolerance float64, nThreads int, matrixType matrix.MatrixType, opts ...Option) (matrix.Matrix, int, float64) {
+	res, err := NewSolver(append([]Option{
+		WithThreads(nThreads),
+		WithMatrixType(matrixType),
+		WithMaxIters(maxIters),
+		WithTolerance(tolerance),
+	}, opts...)...).Solve(NewProblem(initialValue, nDim))
+	if err != nil {
+		panic(err)
 	}
-	return runMultithreadedJacobi(initialValue, nDim, maxIters, tolerance, nThreads, matrixType)
+
+	return res.Matrix, res.Iterations, res.MaxDiff
+}
+
+// RunJacobiContext is like RunJacobi, but stops at the end of the current iteration once the context is done,
+// in which case the partial matrix is returned along with the iteration reached and the context error
+// Unlike RunJacobi, it returns an error if the parameters are invalid
+func RunJacobiContext(ctx context.Context, initialValue float64, nDim int, maxIters int, tolerance float64, nThreads int, matrixType matrix.MatrixType, opts ...Option) (matrix.Matrix, int, float64, error) {
+	res, err := NewSolver(append([]Option{
+		WithThreads(nThreads),
+		WithMatrixType(matrixType),
+		WithMaxIters(maxIters),
+		WithTolerance(tolerance),
+	}, opts...)...).SolveContext(ctx, NewProblem(initialValue, nDim))
+
+	return res.Matrix, res.Iterations, res.MaxDiff, err
 }
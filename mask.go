@@ -0,0 +1,71 @@
+package jacobi
+
+const (
+	// FreeCell is the kind of a cell computed by the jacobi method
+	FreeCell CellKind = iota
+	// FixedCell is the kind of a cell which keeps a fixed value, e.g. a heater or a cooled pin
+	FixedCell
+	// VoidCell is the kind of a cell which doesn't conduct heat, hence its adjacent cells are insulated from it
+	VoidCell
+)
+
+// CellKind defines how a cell of the simulated space is computed
+type CellKind uint8
+
+// Cell defines how a cell of the simulated space is computed
+type Cell struct {
+	Kind CellKind
+	// Value is the value of a fixed cell
+	Value float64
+}
+
+// Mask defines how each cell of the simulated space is computed
+// It has the same size as the problem matrix, hence cells are indexed the same way and edges cells are ignored
+type Mask [][]Cell
+
+// NewMask creates a mask for a problem whose side length is nDim, in which every cell is free
+func NewMask(nDim int) Mask {
+	return NewRectangularMask(nDim, nDim)
+}
+
+// NewRectangularMask creates a mask for a problem with the given number of rows and columns, in which every cell is free
+func NewRectangularMask(rows, cols int) Mask {
+	rows, cols = rows+2, cols+2
+	cells, mask := make([]Cell, rows*cols), make(Mask, rows)
+	for i := 0; i < rows; i++ {
+		mask[i] = cells[i*cols : (i+1)*cols]
+	}
+
+	return mask
+}
+
+// validate checks the mask matches the size of the problem matrix
+func (mask Mask) validate(rows, cols int) error {
+	if len(mask) != rows {
+		return ErrMaskSize
+	}
+	for _, row := range mask {
+		if len(row) != cols {
+			return ErrMaskSize
+		}
+	}
+	return nil
+}
+
+// homogeneous returns a copy of the mask whose fixed cells keep a zero value
+func (mask Mask) homogeneous() Mask {
+	res := NewRectangularMask(len(mask)-2, len(mask[0])-2)
+	for i, row := range mask {
+		for j, cell := range row {
+			res[i][j].Kind = cell.Kind
+		}
+	}
+
+	return res
+}
+
+// isVoid returns true if the cell in the (i, j) position is a void cell
+// Edges cells are only void in a wrapped mask, as the copy of a void inner cell next to the opposite periodic edge
+func (mask Mask) isVoid(i, j int) bool {
+	return mask[i][j].Kind == VoidCell
+}
@@ -0,0 +1,86 @@
+package jacobi
+
+import (
+	"context"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"math"
+)
+
+// refinementFactor is the reduction of the residual, relative to the current one, that each correction aims for
+// Single precision sweeps can't reduce it much further than that when the correction is already small
+const refinementFactor = 1.0e-2
+
+// computeResidual computes the difference between a jacobi sweep of the solution and the solution itself on every inner cell,
+// which is the right-hand side of the correction problem, and returns its maximum, i.e. the maxDiff the jacobi method would get
+func computeResidual[T matrix.Float](problem Problem, sol matrix.MatrixOf[T], residual matrix.Matrix, st stencil[T]) float64 {
+	rows, cols := problem.size()
+	maxDiff := T(0.0)
+
+	updateGhostCells(problem, sol, st)
+	for i := 1; i <= rows; i++ {
+		for j := 1; j <= cols; j++ {
+			diff := st.newValue(i, j, sol.GetCell(i, j), sol.GetCell(i-1, j), sol.GetCell(i+1, j), sol.GetCell(i, j-1), sol.GetCell(i, j+1)) - sol.GetCell(i, j)
+			residual.SetCell(i, j, float64(diff))
+			maxDiff = max(maxDiff, utils.Abs(diff))
+		}
+	}
+
+	return float64(maxDiff)
+}
+
+// runMixedPrecisionJacobi runs the jacobi method as an iterative refinement: the residual of the solution is computed in the precision
+// of the solver matrices (T), and the correction which cancels it is solved by single precision jacobi sweeps, either single-threaded
+// or multi-threaded, and then added to the solution
+// The number of iterations is the number of sweeps in both precisions, and it's bounded by the maximum number of iterations
+func runMixedPrecisionJacobi[T matrix.Float](ctx context.Context, problem Problem, opts options) (ResultOf[T], error) {
+	var res ResultOf[T]
+	rows, cols := problem.size()
+
+	sol, st := newMatrix[T](problem, opts.matrixType), newStencil[T](problem)
+	residual := newMatrix[float64](problem.correctionProblem(nil), opts.matrixType)
+	correction := problem.correctionProblem(residual)
+	// Corrections are notified as a whole, as they are solved for a different problem
+	correctionOpts := opts
+	correctionOpts.onIteration = nil
+
+	var err error
+	res.Matrix, res.MaxDiff = sol, math.MaxFloat64
+	for res.Iterations < opts.maxIters {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		res.MaxDiff = computeResidual(problem, sol, residual, st)
+		res.HighPrecisionSweeps++
+		res.Iterations++
+		opts.notifyIteration(res.Iterations, res.MaxDiff)
+
+		if res.MaxDiff <= opts.tolerance || res.Iterations == opts.maxIters {
+			break
+		}
+
+		correctionOpts.maxIters = opts.maxIters - res.Iterations
+		correctionOpts.tolerance = math.Max(opts.tolerance, refinementFactor*res.MaxDiff)
+		var corr matrix.MatrixOf[float32]
+		var nIters int
+		corr, nIters, _, err = runJacobi[float32](ctx, correction, correctionOpts)
+		res.LowPrecisionSweeps += nIters
+		res.Iterations += nIters
+
+		// A partial correction still improves the solution
+		for i := 1; i <= rows; i++ {
+			for j := 1; j <= cols; j++ {
+				sol.SetCell(i, j, sol.GetCell(i, j)+T(corr.GetCell(i, j)))
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	// Leave the edges consistent with the resulting inner cells
+	updateGhostCells(problem, sol, st)
+
+	return res, err
+}
@@ -0,0 +1,39 @@
+package matrix
+
+// Boundary defines the values of the cells of one of the edges of a matrix
+type Boundary interface {
+	// Value retrieves the value of the k-th cell of an edge made of n cells, corners included
+	Value(k, n int) float64
+}
+
+// ConstantBoundary represents an edge whose cells have all the same value
+type ConstantBoundary float64
+
+// Value retrieves the value of the k-th cell of an edge made of n cells, corners included
+func (boundary ConstantBoundary) Value(k, n int) float64 {
+	return float64(boundary)
+}
+
+// ProfileBoundary represents an edge whose cells values are given by a function of the position along the edge,
+// which goes from 0.0 in the first corner (top or left) to 1.0 in the last one (bottom or right)
+type ProfileBoundary func(x float64) float64
+
+// Value retrieves the value of the k-th cell of an edge made of n cells, corners included
+func (boundary ProfileBoundary) Value(k, n int) float64 {
+	return boundary(float64(k) / float64(n-1))
+}
+
+// ValuesBoundary represents an edge whose cells values are explicitly given, corners included
+type ValuesBoundary []float64
+
+// Value retrieves the value of the k-th cell of an edge made of n cells, corners included
+func (boundary ValuesBoundary) Value(k, n int) float64 {
+	return boundary[k]
+}
+
+// LinearBoundary returns a boundary whose values go linearly from one corner value to the other one
+func LinearBoundary(from, to float64) ProfileBoundary {
+	return func(x float64) float64 {
+		return from + (to-from)*x
+	}
+}
@@ -32,19 +32,31 @@ func (matrixType MatrixType) ToString() string {
 	}
 }
 
-// Matrix defines a matrix
-type Matrix interface {
+// Float is the constraint of the type of the matrices cells, either single or double precision
+type Float interface {
+	~float32 | ~float64
+}
+
+// MatrixOf defines a matrix whose cells are of type T
+type MatrixOf[T Float] interface {
 	utils.Stringable
-	MatrixCloneable
+	MatrixCloneable[T]
 	// GetCell retrieves the value in the (i, j) position
-	GetCell(i, j int) float64
+	GetCell(i, j int) T
 	// SetCell updates the value in the (i, j) position
-	SetCell(i, j int, value float64)
-	// GetNDim retrieves the length of the matrix
+	SetCell(i, j int, value T)
+	// GetNDim retrieves the length of the matrix, which is its number of rows
 	GetNDim() int
+	// GetRows retrieves the number of rows of the matrix
+	GetRows() int
+	// GetCols retrieves the number of columns of the matrix
+	GetCols() int
 }
 
-// Coords defines a 2D square
+// Matrix defines a matrix of double precision cells
+type Matrix = MatrixOf[float64]
+
+// Coords defines a 2D rectangle
 type Coords struct {
 	// Top-left corner and bottom-right corner
 	X0, Y0, X1, Y1 int
@@ -53,28 +65,28 @@ type Coords struct {
 // MatrixDef defines a submatrix inside a Matrix
 type MatrixDef struct {
 	Coords Coords
-	// Precomputed matrix size: len(matrix)
-	Size int
+	// Precomputed matrix size: number of rows and columns
+	Rows, Cols int
 }
 
 // MatrixCloneable represents a matrix that can be cloned
-type MatrixCloneable interface {
-	// Clone returns a Matrix
-	Clone(matDef MatrixDef) Matrix
+type MatrixCloneable[T Float] interface {
+	// Clone returns a matrix of the same type
+	Clone(matDef MatrixDef) MatrixOf[T]
 }
 
-// CompareMatrices returns true if both matrices contain equal cells or both are nil,
+// CompareMatrices returns true if both matrices have the same number of rows and columns and contain equal cells,
 // otherwise returns false
-func CompareMatrices(matA, matB Matrix) bool {
-	matNDim := matA.GetNDim()
+func CompareMatrices[T Float](matA, matB MatrixOf[T]) bool {
+	rows, cols := matA.GetRows(), matA.GetCols()
 
-	if matNDim != matB.GetNDim() {
+	if rows != matB.GetRows() || cols != matB.GetCols() {
 		return false
 	}
 
-	for i := 0; i < matNDim; i++ {
-		for j := 0; j < matNDim; j++ {
-			if !utils.CompareFloats(matA.GetCell(i, j), matB.GetCell(i, j), utils.Epsilon) {
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if !utils.CompareFloats(float64(matA.GetCell(i, j)), float64(matB.GetCell(i, j)), utils.Epsilon) {
 				return false
 			}
 		}
@@ -5,63 +5,89 @@ import (
 	"strings"
 )
 
-// OneDimMatrix represents a matrix in a 1D array
-type OneDimMatrix struct {
-	matrix []float64
-	nDim   int
+// OneDimMatrixOf represents a matrix in a 1D array, whose cells are of type T
+type OneDimMatrixOf[T Float] struct {
+	matrix       []T
+	nRows, nCols int
 }
 
+// OneDimMatrix represents a matrix of double precision cells in a 1D array
+type OneDimMatrix = OneDimMatrixOf[float64]
+
 // NewOneDimMatrix creates and initializes a 2D array representing a matrix
 func NewOneDimMatrix(initialValue float64, n int, topBoundary, bottomBoundary, leftBoundary, rightBoundary float64) OneDimMatrix {
-	mat := OneDimMatrix{
-		matrix: make([]float64, n*n),
-		nDim:   n,
+	return NewOneDimMatrixWithBoundaries(initialValue, n, ConstantBoundary(topBoundary), ConstantBoundary(bottomBoundary), ConstantBoundary(leftBoundary), ConstantBoundary(rightBoundary))
+}
+
+// NewOneDimMatrixWithBoundaries creates and initializes a 2D array representing a matrix whose edges values may vary along the edge
+func NewOneDimMatrixWithBoundaries(initialValue float64, n int, topBoundary, bottomBoundary, leftBoundary, rightBoundary Boundary) OneDimMatrix {
+	return NewRectangularOneDimMatrix(initialValue, n, n, topBoundary, bottomBoundary, leftBoundary, rightBoundary)
+}
+
+// NewRectangularOneDimMatrix creates and initializes a 2D array representing a matrix with the given number of rows and columns
+// The top and bottom edges are made of cols cells, and the left and right edges of rows cells
+func NewRectangularOneDimMatrix[T Float](initialValue T, rows, cols int, topBoundary, bottomBoundary, leftBoundary, rightBoundary Boundary) OneDimMatrixOf[T] {
+	mat := OneDimMatrixOf[T]{
+		matrix: make([]T, rows*cols),
+		nRows:  rows,
+		nCols:  cols,
 	}
 
 	// Init inner cells value
-	for i := 0; i < n; i++ {
-		for j := 0; j < n; j++ {
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
 			mat.SetCell(i, j, initialValue)
 		}
 	}
 
-	// Init top, right and left boundaries
-	for i := 0; i < n; i++ {
-		mat.SetCell(0, i, topBoundary)
-		mat.SetCell(i, 0, leftBoundary)
-		mat.SetCell(i, n-1, rightBoundary)
+	// Init right and left boundaries
+	for i := 0; i < rows; i++ {
+		mat.SetCell(i, 0, T(leftBoundary.Value(i, rows)))
+		mat.SetCell(i, cols-1, T(rightBoundary.Value(i, rows)))
 	}
 
-	// Init bottom boundary
-	for j := 0; j < n; j++ {
-		mat.SetCell(n-1, j, bottomBoundary)
+	// Init top and bottom boundaries
+	for j := 0; j < cols; j++ {
+		mat.SetCell(0, j, T(topBoundary.Value(j, cols)))
+		mat.SetCell(rows-1, j, T(bottomBoundary.Value(j, cols)))
 	}
 
 	return mat
 }
 
 // GetCell retrieves the value in the (i, j) position
-func (mat OneDimMatrix) GetCell(i, j int) float64 {
-	return mat.matrix[i*mat.nDim+j]
+func (mat OneDimMatrixOf[T]) GetCell(i, j int) T {
+	return mat.matrix[i*mat.nCols+j]
 }
 
 // SetCell updates the value in the (i, j) position
-func (mat OneDimMatrix) SetCell(i, j int, value float64) {
-	mat.matrix[i*mat.nDim+j] = value
+func (mat OneDimMatrixOf[T]) SetCell(i, j int, value T) {
+	mat.matrix[i*mat.nCols+j] = value
+}
+
+// GetNDim retrieves the length of the matrix, which is its number of rows
+func (mat OneDimMatrixOf[T]) GetNDim() int {
+	return mat.nRows
+}
+
+// GetRows retrieves the number of rows of the matrix
+func (mat OneDimMatrixOf[T]) GetRows() int {
+	return mat.nRows
 }
 
-// GetNDim retrieves the length of the matrix
-func (mat OneDimMatrix) GetNDim() int {
-	return mat.nDim
+// GetCols retrieves the number of columns of the matrix
+func (mat OneDimMatrixOf[T]) GetCols() int {
+	return mat.nCols
 }
 
 // Clone clones the portion of the matrix specified by a OneDimMatrixDef
-func (mat OneDimMatrix) Clone(matDef MatrixDef) Matrix {
-	x0, y0, x1, y1, length := matDef.Coords.X0, matDef.Coords.Y0, matDef.Coords.X1, matDef.Coords.Y1, matDef.Size
+func (mat OneDimMatrixOf[T]) Clone(matDef MatrixDef) MatrixOf[T] {
+	x0, y0, x1, y1, rows, cols := matDef.Coords.X0, matDef.Coords.Y0, matDef.Coords.X1, matDef.Coords.Y1, matDef.Rows, matDef.Cols
 
-	clone := OneDimMatrix{
-		nDim:   length,
-		matrix: make([]float64, length*length),
+	clone := OneDimMatrixOf[T]{
+		nRows:  rows,
+		nCols:  cols,
+		matrix: make([]T, rows*cols),
 	}
 	for i := x0; i <= x1; i++ {
 		for j := y0; j <= y1; j++ {
@@ -73,16 +99,16 @@ func (mat OneDimMatrix) Clone(matDef MatrixDef) Matrix {
 }
 
 // ToString returns the matrix in a human-readable format
-func (mat OneDimMatrix) ToString() string {
+func (mat OneDimMatrixOf[T]) ToString() string {
 	var resSb strings.Builder
-	matStrBuf := make([]string, mat.nDim)
-	rowStrBuf := make([]string, mat.nDim)
+	matStrBuf := make([]string, mat.nRows)
+	rowStrBuf := make([]string, mat.nCols)
 
-	for i := 0; i < mat.nDim; i++ {
-		for j := 0; j < mat.nDim; j++ {
-			rowStrBuf[j] = fmt.Sprintf("%.4f", mat.matrix[i*mat.nDim+j])
+	for i := 0; i < mat.nRows; i++ {
+		for j := 0; j < mat.nCols; j++ {
+			rowStrBuf[j] = fmt.Sprintf("%.4f", mat.matrix[i*mat.nCols+j])
 		}
-		matStrBuf[i] = strings.Join(matStrBuf, " ")
+		matStrBuf[i] = strings.Join(rowStrBuf, " ")
 	}
 	resSb.WriteString(strings.Join(matStrBuf, "\n"))
 
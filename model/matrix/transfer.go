@@ -0,0 +1,35 @@
+package matrix
+
+// Restrict computes the inner cells of the coarse matrix, which has half the inner rows and columns of the fine one,
+// as the mean of the 2x2 block of inner cells of the fine matrix they cover
+// Edges of the coarse matrix are left untouched
+func Restrict[T Float](fine, coarse MatrixOf[T]) {
+	for i := 1; i < coarse.GetRows()-1; i++ {
+		for j := 1; j < coarse.GetCols()-1; j++ {
+			coarse.SetCell(i, j, 0.25*(fine.GetCell(2*i-1, 2*j-1)+fine.GetCell(2*i-1, 2*j)+fine.GetCell(2*i, 2*j-1)+fine.GetCell(2*i, 2*j)))
+		}
+	}
+}
+
+// Prolongate computes the inner cells of the fine matrix, which has twice the inner rows and columns of the coarse one,
+// by bilinear interpolation of the cells of the coarse matrix, edges included
+// Each coarse cell lies at the center of the 2x2 block of fine cells it covers, so every fine cell is weighted 9/16 by the coarse
+// cell covering it, 3/16 by the adjacent ones on its side and 1/16 by the one on its diagonal. Edges of the fine matrix are left untouched
+func Prolongate[T Float](coarse, fine MatrixOf[T]) {
+	for i := 1; i < fine.GetRows()-1; i++ {
+		// Coarse row covering the fine cell and the adjacent one on its side
+		iNear, iFar := (i+1)/2, (i+1)/2+1
+		if i%2 == 1 {
+			iFar = iNear - 1
+		}
+
+		for j := 1; j < fine.GetCols()-1; j++ {
+			jNear, jFar := (j+1)/2, (j+1)/2+1
+			if j%2 == 1 {
+				jFar = jNear - 1
+			}
+
+			fine.SetCell(i, j, (9*coarse.GetCell(iNear, jNear)+3*coarse.GetCell(iFar, jNear)+3*coarse.GetCell(iNear, jFar)+coarse.GetCell(iFar, jFar))/16)
+		}
+	}
+}
@@ -5,73 +5,100 @@ import (
 	"strings"
 )
 
-// TwoDimMatrix represents a matrix in a 2D array
-type TwoDimMatrix []row
+// TwoDimMatrixOf represents a matrix in a 2D array, whose cells are of type T
+type TwoDimMatrixOf[T Float] []row[T]
+
+// TwoDimMatrix represents a matrix of double precision cells in a 2D array
+type TwoDimMatrix = TwoDimMatrixOf[float64]
 
 // row represents a 1D array belonging to a TwoDimMatrix
-type row []float64
+type row[T Float] []T
 
 // NewTwoDimMatrix creates and initializes a 2D array representing a matrix
 func NewTwoDimMatrix(initialValue float64, n int, topBoundary, bottomBoundary, leftBoundary, rightBoundary float64, matrixType MatrixType) TwoDimMatrix {
+	return NewTwoDimMatrixWithBoundaries(initialValue, n, ConstantBoundary(topBoundary), ConstantBoundary(bottomBoundary), ConstantBoundary(leftBoundary), ConstantBoundary(rightBoundary), matrixType)
+}
+
+// NewTwoDimMatrixWithBoundaries creates and initializes a 2D array representing a matrix whose edges values may vary along the edge
+func NewTwoDimMatrixWithBoundaries(initialValue float64, n int, topBoundary, bottomBoundary, leftBoundary, rightBoundary Boundary, matrixType MatrixType) TwoDimMatrix {
+	return NewRectangularTwoDimMatrix(initialValue, n, n, topBoundary, bottomBoundary, leftBoundary, rightBoundary, matrixType)
+}
+
+// NewRectangularTwoDimMatrix creates and initializes a 2D array representing a matrix with the given number of rows and columns
+// The top and bottom edges are made of cols cells, and the left and right edges of rows cells
+func NewRectangularTwoDimMatrix[T Float](initialValue T, rows, cols int, topBoundary, bottomBoundary, leftBoundary, rightBoundary Boundary, matrixType MatrixType) TwoDimMatrixOf[T] {
 	// Allocate matrix
-	mat := make(TwoDimMatrix, n)
+	mat := make(TwoDimMatrixOf[T], rows)
 	if matrixType == TwoDimDividedMatrixType {
 		// Not ensured to be contiguous
-		for i := 0; i < n; i++ {
-			mat[i] = make(row, n)
+		for i := 0; i < rows; i++ {
+			mat[i] = make(row[T], cols)
 		}
 	} else {
 		// Ensure contiguous allocation
-		rows := make(row, n*n)
-		for i := 0; i < n; i++ {
-			mat[i] = rows[i*n : (i+1)*n]
+		cells := make(row[T], rows*cols)
+		for i := 0; i < rows; i++ {
+			mat[i] = cells[i*cols : (i+1)*cols]
 		}
 	}
 
 	// Init inner cells
-	for i := 1; i < n-1; i++ {
-		for j := 0; j < n-1; j++ {
+	for i := 1; i < rows-1; i++ {
+		for j := 0; j < cols-1; j++ {
 			mat.SetCell(i, j, initialValue)
 		}
 	}
 
-	// Init top, right and left boundaries
-	for i := 0; i < n; i++ {
-		mat.SetCell(0, i, topBoundary)
-		mat.SetCell(i, 0, leftBoundary)
-		mat.SetCell(i, n-1, rightBoundary)
+	// Init right and left boundaries
+	for i := 0; i < rows; i++ {
+		mat.SetCell(i, 0, T(leftBoundary.Value(i, rows)))
+		mat.SetCell(i, cols-1, T(rightBoundary.Value(i, rows)))
 	}
 
-	// Init bottom boundary
-	for j := 0; j < n; j++ {
-		mat.SetCell(n-1, j, bottomBoundary)
+	// Init top and bottom boundaries
+	for j := 0; j < cols; j++ {
+		mat.SetCell(0, j, T(topBoundary.Value(j, cols)))
+		mat.SetCell(rows-1, j, T(bottomBoundary.Value(j, cols)))
 	}
 
 	return mat
 }
 
 // GetCell retrieves the value in the (i, j) position
-func (mat TwoDimMatrix) GetCell(i, j int) float64 {
+func (mat TwoDimMatrixOf[T]) GetCell(i, j int) T {
 	return mat[i][j]
 }
 
 // SetCell updates the value in the (i, j) position
-func (mat TwoDimMatrix) SetCell(i, j int, value float64) {
+func (mat TwoDimMatrixOf[T]) SetCell(i, j int, value T) {
 	mat[i][j] = value
 }
 
-// GetNDim retrieves the length of the matrix
-func (mat TwoDimMatrix) GetNDim() int {
+// GetNDim retrieves the length of the matrix, which is its number of rows
+func (mat TwoDimMatrixOf[T]) GetNDim() int {
 	return len(mat)
 }
 
+// GetRows retrieves the number of rows of the matrix
+func (mat TwoDimMatrixOf[T]) GetRows() int {
+	return len(mat)
+}
+
+// GetCols retrieves the number of columns of the matrix
+func (mat TwoDimMatrixOf[T]) GetCols() int {
+	if len(mat) == 0 {
+		return 0
+	}
+	return len(mat[0])
+}
+
 // Clone clones the portion of the matrix specified by a TwoDimMatrixDef
-func (mat TwoDimMatrix) Clone(matDef MatrixDef) Matrix {
-	x0, y0, x1, y1, length := matDef.Coords.X0, matDef.Coords.Y0, matDef.Coords.X1, matDef.Coords.Y1, matDef.Size
+func (mat TwoDimMatrixOf[T]) Clone(matDef MatrixDef) MatrixOf[T] {
+	x0, y0, x1, y1, rows, cols := matDef.Coords.X0, matDef.Coords.Y0, matDef.Coords.X1, matDef.Coords.Y1, matDef.Rows, matDef.Cols
 
-	clone := make(TwoDimMatrix, length)
+	clone := make(TwoDimMatrixOf[T], rows)
 	for i := x0; i <= x1; i++ {
-		clone[i-x0] = make(row, length)
+		clone[i-x0] = make(row[T], cols)
 		for j := y0; j <= y1; j++ {
 			clone.SetCell(i-x0, j-y0, mat.GetCell(i, j))
 		}
@@ -81,7 +108,7 @@ func (mat TwoDimMatrix) Clone(matDef MatrixDef) Matrix {
 }
 
 // ToString returns the row in a human-readable format
-func (row row) ToString() string {
+func (row row[T]) ToString() string {
 	var resSb strings.Builder
 	strBuf := make([]string, len(row))
 
@@ -94,7 +121,7 @@ func (row row) ToString() string {
 }
 
 // ToString returns the matrix in a human-readable format
-func (mat TwoDimMatrix) ToString() string {
+func (mat TwoDimMatrixOf[T]) ToString() string {
 	var resSb strings.Builder
 	strBuf := make([]string, len(mat))
 
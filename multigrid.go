@@ -0,0 +1,221 @@
+package jacobi
+
+import (
+	"context"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"math"
+)
+
+const (
+	// Damped jacobi sweeps done on each level before and after visiting the coarser one
+	preSmoothingSweeps, postSmoothingSweeps = 2, 2
+	// Damped jacobi sweeps done on the coarsest level, which is expected to be small enough for them to solve it
+	coarsestSweeps = 50
+	// Minimum number of rows and columns of the coarsest level
+	minLevelSize = 2
+	// The residual of a level is proportional to its squared spacing, which is doubled by the coarser level
+	coarseResidualFactor = 4.0
+)
+
+// mgLevel is a level of the multigrid method, whose matrices cells are of type T
+// The finest level solves the problem, and each coarser one solves the correction of the finer one, on a grid
+// with half the rows and columns
+type mgLevel[T matrix.Float] struct {
+	problem Problem
+	st      stencil[T]
+	// Factor by which the edge cells of fixed edges of a coarse level are extrapolated from the inner cells next to them, zero for the finest level
+	edgeFactor T
+	// Current solution of the level, and aux matrix for the jacobi sweeps and the prolongated corrections
+	mat, aux matrix.MatrixOf[T]
+	// Residual of the solution, which is restricted to the coarser level
+	residual matrix.Matrix
+}
+
+// maxLevels computes the maximum number of levels of a problem, as each one halves the rows and columns of the finer one
+func maxLevels(rows, cols int) int {
+	levels := 1
+	for rows%2 == 0 && cols%2 == 0 && rows/2 >= minLevelSize && cols/2 >= minLevelSize {
+		rows, cols, levels = rows/2, cols/2, levels+1
+	}
+	return levels
+}
+
+// validateMultigrid checks the problem can be solved by the multigrid method with the given number of levels
+// Fields which aren't uniform can't be coarsened yet
+func validateMultigrid(problem Problem, levels int) error {
+	if problem.Mask != nil || problem.Conductivity != nil || problem.ConductivityX != nil || problem.ConductivityY != nil {
+		return ErrUnsupportedMultigridProblem
+	}
+	return validateLevels(problem, levels)
+}
+
+// validateLevels checks the problem can be coarsened into the given number of levels
+func validateLevels(problem Problem, levels int) error {
+	if rows, cols := problem.size(); levels > maxLevels(rows, cols) {
+		return ErrTooManyLevels
+	}
+	return nil
+}
+
+// newLevel creates the l-th level of the multigrid method for the problem
+func newLevel[T matrix.Float](problem Problem, l int, matrixType matrix.MatrixType) *mgLevel[T] {
+	rows, cols := problem.size()
+	mat := newMatrix[T](problem, matrixType)
+
+	// The first and last inner cells of the l-th level are at the center of the first and last 2^l cells of the finest one,
+	// so they're (0.5 + 2^-(l+1)) times the spacing away from the edges, instead of once the spacing
+	var edgeFactor T
+	if l > 0 {
+		distance := 0.5 + math.Pow(2, -float64(l+1))
+		edgeFactor = T(-(1 - distance) / distance)
+	}
+
+	return &mgLevel[T]{
+		problem:    problem,
+		st:         newStencil[T](problem),
+		edgeFactor: edgeFactor,
+		mat:        mat,
+		aux: mat.Clone(matrix.MatrixDef{
+			Coords: matrix.Coords{X0: 0, Y0: 0, X1: rows + 1, Y1: cols + 1},
+			Rows:   rows + 2,
+			Cols:   cols + 2,
+		}),
+		residual: newMatrix[float64](problem.correctionProblem(nil), matrixType),
+	}
+}
+
+// newLevels creates the levels of the multigrid method, from the finest to the coarsest
+// If the number of levels of the options is zero, the problem is coarsened as many times as possible
+func newLevels[T matrix.Float](problem Problem, opts options) []*mgLevel[T] {
+	nLevels := opts.levels
+	if nLevels == 0 {
+		nLevels = maxLevels(problem.size())
+	}
+
+	levels := make([]*mgLevel[T], nLevels)
+	levels[0] = newLevel[T](problem, 0, opts.matrixType)
+	for l := 1; l < nLevels; l++ {
+		coarse := levels[l-1].problem.coarseProblem(nil)
+		coarse.residual = newMatrix[float64](coarse, opts.matrixType)
+		levels[l] = newLevel[T](coarse, l, opts.matrixType)
+	}
+
+	return levels
+}
+
+// updateGhostCells updates the edge cells of the level
+// Fixed edges of coarse levels, whose value is zero, are extrapolated for the correction to be zero at the actual edges instead of at their edge cells
+func (lvl *mgLevel[T]) updateGhostCells() {
+	updateGhostCells(lvl.problem, lvl.mat, lvl.st)
+	if lvl.edgeFactor == 0 {
+		return
+	}
+
+	rows, cols := lvl.problem.size()
+	conditions := lvl.problem.Conditions
+	for j := 1; j <= cols; j++ {
+		if conditions.Top.Kind == Dirichlet {
+			lvl.mat.SetCell(0, j, lvl.edgeFactor*lvl.mat.GetCell(1, j))
+		}
+		if conditions.Bottom.Kind == Dirichlet {
+			lvl.mat.SetCell(rows+1, j, lvl.edgeFactor*lvl.mat.GetCell(rows, j))
+		}
+	}
+	for i := 1; i <= rows; i++ {
+		if conditions.Left.Kind == Dirichlet {
+			lvl.mat.SetCell(i, 0, lvl.edgeFactor*lvl.mat.GetCell(i, 1))
+		}
+		if conditions.Right.Kind == Dirichlet {
+			lvl.mat.SetCell(i, cols+1, lvl.edgeFactor*lvl.mat.GetCell(i, cols))
+		}
+	}
+}
+
+// smooth does the given number of damped jacobi sweeps on the level
+func (lvl *mgLevel[T]) smooth(nSweeps int, w T) {
+	rows, cols := lvl.problem.size()
+
+	for sweep := 0; sweep < nSweeps; sweep++ {
+		lvl.updateGhostCells()
+		for i := 1; i <= rows; i++ {
+			for j := 1; j <= cols; j++ {
+				// Compute new value with 3x3 filter with no corners
+				lvl.aux.SetCell(i, j, relax(lvl.mat.GetCell(i, j), lvl.st.newValue(i, j, lvl.mat.GetCell(i, j), lvl.mat.GetCell(i-1, j), lvl.mat.GetCell(i+1, j), lvl.mat.GetCell(i, j-1), lvl.mat.GetCell(i, j+1)), w))
+			}
+		}
+
+		// Swap matrices
+		lvl.mat, lvl.aux = lvl.aux, lvl.mat
+	}
+}
+
+// cycle runs a multigrid cycle from the l-th level, which improves its solution by smoothing it and correcting it
+// with the solution of the coarser levels. Coarser levels are visited once by a V-cycle and twice by a W-cycle
+func cycle[T matrix.Float](levels []*mgLevel[T], l int, opts options) {
+	lvl, w := levels[l], T(opts.dampingFactor)
+	if l == len(levels)-1 {
+		lvl.smooth(coarsestSweeps, w)
+		return
+	}
+
+	lvl.smooth(preSmoothingSweeps, w)
+
+	// The coarser level solves for the correction which cancels the residual, starting from no correction
+	coarse := levels[l+1]
+	computeResidual(lvl.problem, lvl.mat, lvl.residual, lvl.st)
+	matrix.Restrict(lvl.residual, coarse.problem.residual)
+	rows, cols := coarse.problem.size()
+	for i := 1; i <= rows; i++ {
+		for j := 1; j <= cols; j++ {
+			coarse.problem.residual.SetCell(i, j, coarseResidualFactor*coarse.problem.residual.GetCell(i, j))
+			coarse.mat.SetCell(i, j, 0.0)
+		}
+	}
+
+	nVisits := 1
+	if opts.cycle == WCycle {
+		nVisits = 2
+	}
+	for visit := 0; visit < nVisits; visit++ {
+		cycle(levels, l+1, opts)
+	}
+
+	// Edges of the coarser level take part in the interpolation of the correction
+	coarse.updateGhostCells()
+	matrix.Prolongate(coarse.mat, lvl.aux)
+	rows, cols = lvl.problem.size()
+	for i := 1; i <= rows; i++ {
+		for j := 1; j <= cols; j++ {
+			lvl.mat.SetCell(i, j, lvl.mat.GetCell(i, j)+lvl.aux.GetCell(i, j))
+		}
+	}
+
+	lvl.smooth(postSmoothingSweeps, w)
+}
+
+// runMultigrid runs the multigrid method, whose matrices cells are of type T, with damped jacobi sweeps as smoother
+// Each iteration is a cycle, after which the maxDiff the jacobi method would get is computed
+// If the context is done, it stops at the end of the current cycle and the partial result is returned along with the context error
+func runMultigrid[T matrix.Float](ctx context.Context, problem Problem, opts options) (matrix.MatrixOf[T], int, float64, error) {
+	levels := newLevels[T](problem, opts)
+	finest := levels[0]
+
+	nIters, maxDiff := 0, math.MaxFloat64
+	var err error
+
+	for maxDiff > opts.tolerance && nIters < opts.maxIters {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		cycle(levels, 0, opts)
+		maxDiff = computeResidual(finest.problem, finest.mat, finest.residual, finest.st)
+		nIters++
+		opts.notifyIteration(nIters, maxDiff)
+	}
+
+	// Leave the edges consistent with the resulting inner cells
+	updateGhostCells(problem, finest.mat, finest.st)
+
+	return finest.mat, nIters, maxDiff, err
+}
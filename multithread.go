@@ -1,28 +1,32 @@
 package jacobi
 
 import (
+	"context"
 	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
 	"math"
-	"os"
 	"sync"
 )
 
-const (
-	invalidProblemParams = 1
-)
-
 type globalParams struct {
-	nWorkers, size int
+	nWorkers, rows, cols int
+}
+
+// reduction is the result of a reduce, which is fanned out by the 'root' worker
+type reduction[T matrix.Float] struct {
+	value T
+	// Whether all workers must stop at the end of the current iteration
+	stop bool
 }
 
-type adjacents struct {
+type adjacents[T matrix.Float] struct {
 	// For sharing values among adjacent workers
-	toTopWorker, toBottomWorker, toRightWorker, toLeftWorker         chan float64
-	fromTopWorker, fromBottomWorker, fromRightWorker, fromLeftWorker chan float64
-	topValues, bottomValues, rightValues, leftValues                 []float64
+	toTopWorker, toBottomWorker, toRightWorker, toLeftWorker         chan T
+	fromTopWorker, fromBottomWorker, fromRightWorker, fromLeftWorker chan T
+	topValues, bottomValues, rightValues, leftValues                 []T
 }
 
-type worker struct {
+type worker[T matrix.Float] struct {
 	// For identifying the worker
 	id, rowNumber, columnNumber int
 	// Global problem parameters
@@ -30,14 +34,17 @@ type worker struct {
 	// Subproblem matrix
 	matDef matrix.MatrixDef
 	// For communicating with adjacent workers
-	adjacents adjacents
+	adjacents adjacents[T]
 	// For reducing maxDiff
-	maxDiffResToRoot, maxDiffResFromRoot []chan float64
+	maxDiffResToRoot   []chan T
+	maxDiffResFromRoot []chan reduction[T]
 }
 
 // Creates the corresponding adjacents for each thread
-func newAdjacents(nThreads, subprobSize int) []adjacents {
-	res, nThreadsSqrt := make([]adjacents, nThreads), int(math.Sqrt(float64(nThreads)))
+// Values shared with the top and bottom workers are a row of the subproblem, and the ones shared with the left and right workers are a column
+// Workers next to periodic edges are connected to the workers next to the opposite edge
+func newAdjacents[T matrix.Float](nThreads, subprobRows, subprobCols int, periodicRows, periodicColumns bool) []adjacents[T] {
+	res, nThreadsSqrt := make([]adjacents[T], nThreads), int(math.Sqrt(float64(nThreads)))
 
 	for id := 0; id < nThreads; id++ {
 		rowN, columnN := int(id/nThreadsSqrt), id%nThreadsSqrt
@@ -45,23 +52,23 @@ func newAdjacents(nThreads, subprobSize int) []adjacents {
 		if rowN == 0 {
 			if columnN == 0 {
 				// Worker for top-left corner matrix
-				res[id] = adjacents{
+				res[id] = adjacents[T]{
 					toTopWorker:      nil,
 					fromTopWorker:    nil,
-					toBottomWorker:   make(chan float64, subprobSize),
-					fromBottomWorker: make(chan float64, subprobSize),
-					toRightWorker:    make(chan float64, subprobSize),
-					fromRightWorker:  make(chan float64, subprobSize),
+					toBottomWorker:   make(chan T, subprobCols),
+					fromBottomWorker: make(chan T, subprobCols),
+					toRightWorker:    make(chan T, subprobRows),
+					fromRightWorker:  make(chan T, subprobRows),
 					toLeftWorker:     nil,
 					fromLeftWorker:   nil,
 				}
 			} else if columnN == nThreadsSqrt-1 {
 				// Worker for top-right corner matrix
-				res[id] = adjacents{
+				res[id] = adjacents[T]{
 					toTopWorker:      nil,
 					fromTopWorker:    nil,
-					toBottomWorker:   make(chan float64, subprobSize),
-					fromBottomWorker: make(chan float64, subprobSize),
+					toBottomWorker:   make(chan T, subprobCols),
+					fromBottomWorker: make(chan T, subprobCols),
 					toRightWorker:    nil,
 					fromRightWorker:  nil,
 					toLeftWorker:     res[id-1].fromRightWorker,
@@ -69,13 +76,13 @@ func newAdjacents(nThreads, subprobSize int) []adjacents {
 				}
 			} else {
 				// Worker for top matrix
-				res[id] = adjacents{
+				res[id] = adjacents[T]{
 					toTopWorker:      nil,
 					fromTopWorker:    nil,
-					toBottomWorker:   make(chan float64, subprobSize),
-					fromBottomWorker: make(chan float64, subprobSize),
-					toRightWorker:    make(chan float64, subprobSize),
-					fromRightWorker:  make(chan float64, subprobSize),
+					toBottomWorker:   make(chan T, subprobCols),
+					fromBottomWorker: make(chan T, subprobCols),
+					toRightWorker:    make(chan T, subprobRows),
+					fromRightWorker:  make(chan T, subprobRows),
 					toLeftWorker:     res[id-1].fromRightWorker,
 					fromLeftWorker:   res[id-1].toRightWorker,
 				}
@@ -83,19 +90,19 @@ func newAdjacents(nThreads, subprobSize int) []adjacents {
 		} else if rowN == nThreadsSqrt-1 {
 			if columnN == 0 {
 				// Worker for bottom-left corner matrix
-				res[id] = adjacents{
+				res[id] = adjacents[T]{
 					toTopWorker:      res[id-nThreadsSqrt].fromBottomWorker,
 					fromTopWorker:    res[id-nThreadsSqrt].toBottomWorker,
 					toBottomWorker:   nil,
 					fromBottomWorker: nil,
-					toRightWorker:    make(chan float64, subprobSize),
-					fromRightWorker:  make(chan float64, subprobSize),
+					toRightWorker:    make(chan T, subprobRows),
+					fromRightWorker:  make(chan T, subprobRows),
 					toLeftWorker:     nil,
 					fromLeftWorker:   nil,
 				}
 			} else if columnN == nThreadsSqrt-1 {
 				// Worker for bottom-right corner matrix
-				res[id] = adjacents{
+				res[id] = adjacents[T]{
 					toTopWorker:      res[id-nThreadsSqrt].fromBottomWorker,
 					fromTopWorker:    res[id-nThreadsSqrt].toBottomWorker,
 					toBottomWorker:   nil,
@@ -107,13 +114,13 @@ func newAdjacents(nThreads, subprobSize int) []adjacents {
 				}
 			} else {
 				// Worker for bottom matrix
-				res[id] = adjacents{
+				res[id] = adjacents[T]{
 					toTopWorker:      res[id-nThreadsSqrt].fromBottomWorker,
 					fromTopWorker:    res[id-nThreadsSqrt].toBottomWorker,
 					toBottomWorker:   nil,
 					fromBottomWorker: nil,
-					toRightWorker:    make(chan float64, subprobSize),
-					fromRightWorker:  make(chan float64, subprobSize),
+					toRightWorker:    make(chan T, subprobRows),
+					fromRightWorker:  make(chan T, subprobRows),
 					toLeftWorker:     res[id-1].fromRightWorker,
 					fromLeftWorker:   res[id-1].toRightWorker,
 				}
@@ -121,23 +128,23 @@ func newAdjacents(nThreads, subprobSize int) []adjacents {
 		} else {
 			if columnN == 0 {
 				// Worker for a left side matrix
-				res[id] = adjacents{
+				res[id] = adjacents[T]{
 					toTopWorker:      res[id-nThreadsSqrt].fromBottomWorker,
 					fromTopWorker:    res[id-nThreadsSqrt].toBottomWorker,
-					toBottomWorker:   make(chan float64, subprobSize),
-					fromBottomWorker: make(chan float64, subprobSize),
-					toRightWorker:    make(chan float64, subprobSize),
-					fromRightWorker:  make(chan float64, subprobSize),
+					toBottomWorker:   make(chan T, subprobCols),
+					fromBottomWorker: make(chan T, subprobCols),
+					toRightWorker:    make(chan T, subprobRows),
+					fromRightWorker:  make(chan T, subprobRows),
 					toLeftWorker:     nil,
 					fromLeftWorker:   nil,
 				}
 			} else if columnN == nThreadsSqrt-1 {
 				// Worker for a right side matrix
-				res[id] = adjacents{
+				res[id] = adjacents[T]{
 					toTopWorker:      res[id-nThreadsSqrt].fromBottomWorker,
 					fromTopWorker:    res[id-nThreadsSqrt].toBottomWorker,
-					toBottomWorker:   make(chan float64, subprobSize),
-					fromBottomWorker: make(chan float64, subprobSize),
+					toBottomWorker:   make(chan T, subprobCols),
+					fromBottomWorker: make(chan T, subprobCols),
 					toRightWorker:    nil,
 					fromRightWorker:  nil,
 					toLeftWorker:     res[id-1].fromRightWorker,
@@ -145,30 +152,52 @@ func newAdjacents(nThreads, subprobSize int) []adjacents {
 				}
 			} else {
 				// Worker for any of the rest of the submatrices
-				res[id] = adjacents{
+				res[id] = adjacents[T]{
 					toTopWorker:      res[id-nThreadsSqrt].fromBottomWorker,
 					fromTopWorker:    res[id-nThreadsSqrt].toBottomWorker,
-					toBottomWorker:   make(chan float64, subprobSize),
-					fromBottomWorker: make(chan float64, subprobSize),
-					toRightWorker:    make(chan float64, subprobSize),
-					fromRightWorker:  make(chan float64, subprobSize),
+					toBottomWorker:   make(chan T, subprobCols),
+					fromBottomWorker: make(chan T, subprobCols),
+					toRightWorker:    make(chan T, subprobRows),
+					fromRightWorker:  make(chan T, subprobRows),
 					toLeftWorker:     res[id-1].fromRightWorker,
 					fromLeftWorker:   res[id-1].toRightWorker,
 				}
 			}
 		}
 
-		res[id].topValues = make([]float64, subprobSize)
-		res[id].bottomValues = make([]float64, subprobSize)
-		res[id].leftValues = make([]float64, subprobSize)
-		res[id].rightValues = make([]float64, subprobSize)
+		// Channels of periodic edges are created by the top and left workers, as they come first
+		if periodicRows {
+			if rowN == 0 {
+				res[id].toTopWorker = make(chan T, subprobCols)
+				res[id].fromTopWorker = make(chan T, subprobCols)
+			}
+			if rowN == nThreadsSqrt-1 {
+				res[id].toBottomWorker = res[columnN].fromTopWorker
+				res[id].fromBottomWorker = res[columnN].toTopWorker
+			}
+		}
+		if periodicColumns {
+			if columnN == 0 {
+				res[id].toLeftWorker = make(chan T, subprobRows)
+				res[id].fromLeftWorker = make(chan T, subprobRows)
+			}
+			if columnN == nThreadsSqrt-1 {
+				res[id].toRightWorker = res[rowN*nThreadsSqrt].fromLeftWorker
+				res[id].fromRightWorker = res[rowN*nThreadsSqrt].toLeftWorker
+			}
+		}
+
+		res[id].topValues = make([]T, subprobCols)
+		res[id].bottomValues = make([]T, subprobCols)
+		res[id].leftValues = make([]T, subprobRows)
+		res[id].rightValues = make([]T, subprobRows)
 	}
 
 	return res
 }
 
 // Merges the worker subproblem resulting matrix into the global resulting matrix
-func (worker worker) mergeSubproblem(resMat, subprobResMat matrix.Matrix) {
+func (worker worker[T]) mergeSubproblem(resMat, subprobResMat matrix.MatrixOf[T]) {
 	coords := worker.matDef.Coords
 	x0, y0, x1, y1 := coords.X0, coords.Y0, coords.X1, coords.Y1
 
@@ -181,255 +210,367 @@ func (worker worker) mergeSubproblem(resMat, subprobResMat matrix.Matrix) {
 }
 
 // Computes the new maxDiff taking into account subproblem matrix as well as other workers matrix (like a max-reduce on the global matrix)
-func (worker worker) computeNewMaxDiff(matB, matA matrix.Matrix) float64 {
-	matLen, maxDiff := worker.matDef.Size, 0.0
+func (worker worker[T]) computeNewMaxDiff(ctx context.Context, matB, matA matrix.MatrixOf[T]) reduction[T] {
+	rows, cols, maxDiff := worker.matDef.Rows, worker.matDef.Cols, T(0.0)
 
 	// My subproblem maxDiff
-	for i := 0; i < matLen; i++ {
-		for j := 0; j < matLen; j++ {
-			maxDiff = math.Max(maxDiff, math.Abs(matB.GetCell(i, j)-matA.GetCell(i, j)))
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			maxDiff = max(maxDiff, utils.Abs(matB.GetCell(i, j)-matA.GetCell(i, j)))
 		}
 	}
 
-	return worker.maxReduce(maxDiff)
+	return worker.maxReduce(ctx, maxDiff)
 }
 
 // For the sake of simplicity, reduction is centralized on the 'root' worker, which will fan out the resulting value
 // TODO: Look into a better way to do a parallel reduce
-func (worker worker) maxReduce(maxDiff float64) float64 {
+// The 'root' worker is also the only one checking the context, so that all workers stop at the same iteration
+func (worker worker[T]) reduce(ctx context.Context, value T, op func(a, b T) T) reduction[T] {
 	isRoot := worker.id == 0
 
-	// maximum maxDiff found at this point
-	var maxMaxDiff float64
+	// reduced value at this point
+	var res reduction[T]
 	if isRoot {
 		// Reduction centralized in the 'root' worker
-		// Collect and reduce maxDiff values from all workers
-		maxMaxDiff = maxDiff
+		// Collect and reduce values from all workers
+		res.value = value
 		for i := 0; i < worker.globalParams.nWorkers-1; i++ {
-			maxMaxDiff = math.Max(maxMaxDiff, <-worker.maxDiffResToRoot[i])
+			res.value = op(res.value, <-worker.maxDiffResToRoot[i])
 		}
+		res.stop = ctx.Err() != nil
 
 		// Fan out the result to the rest of the workers
 		for i := 0; i < worker.globalParams.nWorkers-1; i++ {
-			worker.maxDiffResFromRoot[i] <- maxMaxDiff
+			worker.maxDiffResFromRoot[i] <- res
 		}
 	} else {
 		// 'Non-root' workers send their results
-		worker.maxDiffResToRoot[worker.id-1] <- maxDiff
+		worker.maxDiffResToRoot[worker.id-1] <- value
 		// Wait for result calculated by 'Root' worker
-		maxMaxDiff = <-worker.maxDiffResFromRoot[worker.id-1]
+		res = <-worker.maxDiffResFromRoot[worker.id-1]
 	}
 
-	return maxMaxDiff
+	return res
+}
+
+// Max-reduces the maxDiff of all workers
+func (worker worker[T]) maxReduce(ctx context.Context, maxDiff T) reduction[T] {
+	return worker.reduce(ctx, maxDiff, func(a, b T) T { return max(a, b) })
+}
+
+// Sum-reduces the values of all workers
+func (worker worker[T]) sumReduce(ctx context.Context, value T) reduction[T] {
+	return worker.reduce(ctx, value, func(a, b T) T { return a + b })
 }
 
 // Sends the worker outer values to adjacent workers
-func (worker worker) sendOuterCells(mat matrix.Matrix) {
-	matLen, nThreadsSqrt := worker.matDef.Size, int(math.Sqrt(float64(worker.globalParams.nWorkers)))
+// Workers next to a non-periodic edge have no adjacent worker on that side
+func (worker worker[T]) sendOuterCells(mat matrix.MatrixOf[T]) {
+	rows, cols := worker.matDef.Rows, worker.matDef.Cols
 
 	// Since subproblem coordinates never change, this solution
 	// isn't the best one in terms of performance, as these
 	// checks are done for every jacobi iteration
-	if worker.rowNumber != 0 {
-		for j := 0; j < matLen; j++ {
+	if worker.adjacents.toTopWorker != nil {
+		for j := 0; j < cols; j++ {
 			worker.adjacents.toTopWorker <- mat.GetCell(0, j)
 		}
 	}
-	if worker.rowNumber != nThreadsSqrt-1 {
-		for j := 0; j < matLen; j++ {
-			worker.adjacents.toBottomWorker <- mat.GetCell(matLen-1, j)
+	if worker.adjacents.toBottomWorker != nil {
+		for j := 0; j < cols; j++ {
+			worker.adjacents.toBottomWorker <- mat.GetCell(rows-1, j)
 		}
 	}
-	if worker.columnNumber != 0 {
-		for i := 0; i < matLen; i++ {
+	if worker.adjacents.toLeftWorker != nil {
+		for i := 0; i < rows; i++ {
 			worker.adjacents.toLeftWorker <- mat.GetCell(i, 0)
 		}
 	}
-	if worker.columnNumber != nThreadsSqrt-1 {
-		for i := 0; i < matLen; i++ {
-			worker.adjacents.toRightWorker <- mat.GetCell(i, matLen-1)
+	if worker.adjacents.toRightWorker != nil {
+		for i := 0; i < rows; i++ {
+			worker.adjacents.toRightWorker <- mat.GetCell(i, cols-1)
 		}
 	}
 }
 
 // Gets the adjacent workers outer values
-func (worker worker) recvAdjacentCells(mat matrix.Matrix) {
-	matLen, nThreadsSqrt := worker.matDef.Size, int(math.Sqrt(float64(worker.globalParams.nWorkers)))
+func (worker worker[T]) recvAdjacentCells(mat matrix.MatrixOf[T]) {
+	rows, cols := worker.matDef.Rows, worker.matDef.Cols
 
-	if worker.rowNumber != 0 {
-		for j := 0; j < matLen; j++ {
+	if worker.adjacents.fromTopWorker != nil {
+		for j := 0; j < cols; j++ {
 			worker.adjacents.topValues[j] = <-worker.adjacents.fromTopWorker
 		}
 	}
-	if worker.rowNumber != nThreadsSqrt-1 {
-		for j := 0; j < matLen; j++ {
+	if worker.adjacents.fromBottomWorker != nil {
+		for j := 0; j < cols; j++ {
 			worker.adjacents.bottomValues[j] = <-worker.adjacents.fromBottomWorker
 		}
 	}
-	if worker.columnNumber != 0 {
-		for i := 0; i < matLen; i++ {
+	if worker.adjacents.fromLeftWorker != nil {
+		for i := 0; i < rows; i++ {
 			worker.adjacents.leftValues[i] = <-worker.adjacents.fromLeftWorker
 		}
 	}
-	if worker.columnNumber != nThreadsSqrt-1 {
-		for i := 0; i < matLen; i++ {
+	if worker.adjacents.fromRightWorker != nil {
+		for i := 0; i < rows; i++ {
 			worker.adjacents.rightValues[i] = <-worker.adjacents.fromRightWorker
 		}
 	}
 }
 
-// Computes the outer cells of this worker submatrix, which are adjacent to other workers submatrices
-// Returns the updated maxDiff value
-func (worker worker) computeOuterCells(dst, src matrix.Matrix) {
-	matLen := worker.matDef.Size
+// Retrieves the value in the (i, j) position of the worker submatrix, which may be out of it by one cell,
+// in which case the value is taken from the adjacent cells
+func (worker worker[T]) getCell(src matrix.MatrixOf[T], i, j int) T {
+	rows, cols := worker.matDef.Rows, worker.matDef.Cols
+
+	switch {
+	case i < 0:
+		return worker.adjacents.topValues[j]
+	case i == rows:
+		return worker.adjacents.bottomValues[j]
+	case j < 0:
+		return worker.adjacents.leftValues[i]
+	case j == cols:
+		return worker.adjacents.rightValues[i]
+	default:
+		return src.GetCell(i, j)
+	}
+}
+
+// Computes the outer cell in the (i, j) position of this worker submatrix, damped by w
+func (worker worker[T]) computeOuterCell(dst, src matrix.MatrixOf[T], st stencil[T], w T, i, j int) {
+	x0, y0 := worker.matDef.Coords.X0, worker.matDef.Coords.Y0
+
+	dst.SetCell(i, j, relax(src.GetCell(i, j), st.newValue(x0+i, y0+j, src.GetCell(i, j), worker.getCell(src, i-1, j), worker.getCell(src, i+1, j), worker.getCell(src, i, j-1), worker.getCell(src, i, j+1)), w))
+}
+
+// Computes the outer cells of this worker submatrix, which are adjacent to other workers submatrices, damped by w
+func (worker worker[T]) computeOuterCells(dst, src matrix.MatrixOf[T], st stencil[T], w T) {
+	rows, cols := worker.matDef.Rows, worker.matDef.Cols
 
 	// Outer cells in the corners are a special case
-	// Top-left corner
-	dst.SetCell(0, 0, 0.2*(src.GetCell(0, 0)+worker.adjacents.leftValues[0]+src.GetCell(0, 1)+worker.adjacents.topValues[0]+src.GetCell(1, 0)))
-	// Top-right corner
-	dst.SetCell(0, matLen-1, 0.2*(src.GetCell(0, matLen-1)+src.GetCell(0, matLen-2)+worker.adjacents.rightValues[0]+worker.adjacents.topValues[matLen-1]+src.GetCell(1, matLen-1)))
-	// Bottom-left corner
-	dst.SetCell(matLen-1, 0, 0.2*(src.GetCell(matLen-1, 0)+worker.adjacents.leftValues[matLen-1]+src.GetCell(matLen-1, 1)+src.GetCell(matLen-2, 0)+worker.adjacents.bottomValues[0]))
-	// Bottom-right corner
-	dst.SetCell(matLen-1, matLen-1, 0.2*(src.GetCell(matLen-1, matLen-1)+src.GetCell(matLen-1, matLen-2)+worker.adjacents.rightValues[matLen-1]+src.GetCell(matLen-2, matLen-1)+worker.adjacents.bottomValues[matLen-1]))
+	worker.computeOuterCell(dst, src, st, w, 0, 0)
+	worker.computeOuterCell(dst, src, st, w, 0, cols-1)
+	worker.computeOuterCell(dst, src, st, w, rows-1, 0)
+	worker.computeOuterCell(dst, src, st, w, rows-1, cols-1)
 
 	// Rest of outer cells
 	// TODO: This is probably not the best way to compute the outer cells in terms of performance
-	for k := 1; k < matLen-1; k++ {
+	for j := 1; j < cols-1; j++ {
 		// Top outer cells
-		dst.SetCell(0, k, 0.2*(src.GetCell(0, k)+src.GetCell(0, k-1)+src.GetCell(0, k+1)+worker.adjacents.topValues[k]+src.GetCell(1, k)))
+		worker.computeOuterCell(dst, src, st, w, 0, j)
 		// Bottom outer cells
-		dst.SetCell(matLen-1, k, 0.2*(src.GetCell(matLen-1, k)+src.GetCell(matLen-1, k-1)+src.GetCell(matLen-1, k+1)+src.GetCell(matLen-2, k)+worker.adjacents.bottomValues[k]))
+		worker.computeOuterCell(dst, src, st, w, rows-1, j)
+	}
+	for i := 1; i < rows-1; i++ {
 		// Left outer cells
-		dst.SetCell(k, 0, 0.2*(src.GetCell(k, 0)+worker.adjacents.leftValues[k]+src.GetCell(k, 1)+src.GetCell(k-1, 0)+src.GetCell(k+1, 0)))
+		worker.computeOuterCell(dst, src, st, w, i, 0)
 		// Right outer cells
-		dst.SetCell(k, matLen-1, 0.2*(src.GetCell(k, matLen-1)+src.GetCell(k, matLen-2)+worker.adjacents.rightValues[k]+src.GetCell(k-1, matLen-1)+src.GetCell(k+1, matLen-1)))
+		worker.computeOuterCell(dst, src, st, w, i, cols-1)
 	}
 }
 
-func (worker worker) setupBoundaries(initialValue, topBoundary, bottomBoundary, leftBoundary, rightBoundary float64) {
-	matLen, nThreadsSqrt := worker.matDef.Size, int(math.Sqrt(float64(worker.globalParams.nWorkers)))
+// Fills the adjacent cells of the worker with the problem boundaries if the worker submatrix is next to any of them
+func (worker worker[T]) setupBoundaries(initialValue T, boundaries Boundaries) {
+	rows, cols, nThreadsSqrt := worker.matDef.Rows, worker.matDef.Cols, int(math.Sqrt(float64(worker.globalParams.nWorkers)))
+	// Boundaries are defined along the whole edge of the global matrix, corners included
+	x0, y0, nRows, nCols := worker.matDef.Coords.X0, worker.matDef.Coords.Y0, worker.globalParams.rows+2, worker.globalParams.cols+2
 
 	// By default adjacent cell will have the initial value
-	for k := 0; k < matLen; k++ {
-		worker.adjacents.topValues[k] = initialValue
-		worker.adjacents.bottomValues[k] = initialValue
-		worker.adjacents.leftValues[k] = initialValue
-		worker.adjacents.rightValues[k] = initialValue
+	for j := 0; j < cols; j++ {
+		worker.adjacents.topValues[j] = initialValue
+		worker.adjacents.bottomValues[j] = initialValue
+	}
+	for i := 0; i < rows; i++ {
+		worker.adjacents.leftValues[i] = initialValue
+		worker.adjacents.rightValues[i] = initialValue
 	}
 
 	// Overwrite adjacent cells in special cases
 	if worker.rowNumber == 0 {
-		for j := 0; j < matLen; j++ {
-			worker.adjacents.topValues[j] = topBoundary
+		for j := 0; j < cols; j++ {
+			worker.adjacents.topValues[j] = T(boundaries.Top.Value(y0+j, nCols))
 		}
 	}
 	if worker.rowNumber == nThreadsSqrt-1 {
-		for j := 0; j < matLen; j++ {
-			worker.adjacents.bottomValues[j] = bottomBoundary
+		for j := 0; j < cols; j++ {
+			worker.adjacents.bottomValues[j] = T(boundaries.Bottom.Value(y0+j, nCols))
 		}
 	}
 	if worker.columnNumber == 0 {
-		for i := 0; i < matLen; i++ {
-			worker.adjacents.leftValues[i] = leftBoundary
+		for i := 0; i < rows; i++ {
+			worker.adjacents.leftValues[i] = T(boundaries.Left.Value(x0+i, nRows))
 		}
 	}
 	if worker.columnNumber == nThreadsSqrt-1 {
-		for i := 0; i < matLen; i++ {
-			worker.adjacents.rightValues[i] = rightBoundary
+		for i := 0; i < rows; i++ {
+			worker.adjacents.rightValues[i] = T(boundaries.Right.Value(x0+i, nRows))
 		}
 	}
 }
 
-// Runs the jacobi method for the worker subproblem to get its partial result
-func (worker worker) solveSubproblem(resMat matrix.Matrix, initialValue float64, maxIters int, tolerance float64, wg *sync.WaitGroup) {
-	defer wg.Done()
+// Updates the adjacent cells of the worker which belong to an edge of the problem whose values depend on the inner cells
+// Adjacent cells of periodic edges are received from the workers next to the opposite edge instead
+func (worker worker[T]) updateGhostCells(src matrix.MatrixOf[T], problem Problem, st stencil[T]) {
+	if problem.Conditions.isDirichlet() {
+		return
+	}
+
+	rows, cols, nThreadsSqrt := worker.matDef.Rows, worker.matDef.Cols, int(math.Sqrt(float64(worker.globalParams.nWorkers)))
+	x0, y0, spacing := worker.matDef.Coords.X0, worker.matDef.Coords.Y0, problem.spacing()
+	nRows, nCols := worker.globalParams.rows+2, worker.globalParams.cols+2
+	boundaries, conditions := problem.Boundaries, problem.Conditions
 
-	maxDiff, matDef, matLen := math.MaxFloat64, worker.matDef, worker.matDef.Size
+	if worker.rowNumber == 0 && conditions.Top.isComputed() {
+		for j := 0; j < cols; j++ {
+			worker.adjacents.topValues[j] = T(conditions.Top.ghostValue(float64(src.GetCell(0, j)), boundaries.Top.Value(y0+j, nCols), spacing, st.faceConductivity(x0, y0+j, x0-1, y0+j)))
+		}
+	}
+	if worker.rowNumber == nThreadsSqrt-1 && conditions.Bottom.isComputed() {
+		for j := 0; j < cols; j++ {
+			worker.adjacents.bottomValues[j] = T(conditions.Bottom.ghostValue(float64(src.GetCell(rows-1, j)), boundaries.Bottom.Value(y0+j, nCols), spacing, st.faceConductivity(x0+rows-1, y0+j, x0+rows, y0+j)))
+		}
+	}
+	if worker.columnNumber == 0 && conditions.Left.isComputed() {
+		for i := 0; i < rows; i++ {
+			worker.adjacents.leftValues[i] = T(conditions.Left.ghostValue(float64(src.GetCell(i, 0)), boundaries.Left.Value(x0+i, nRows), spacing, st.faceConductivity(x0+i, y0, x0+i, y0-1)))
+		}
+	}
+	if worker.columnNumber == nThreadsSqrt-1 && conditions.Right.isComputed() {
+		for i := 0; i < rows; i++ {
+			worker.adjacents.rightValues[i] = T(conditions.Right.ghostValue(float64(src.GetCell(i, cols-1)), boundaries.Right.Value(x0+i, nRows), spacing, st.faceConductivity(x0+i, y0+cols-1, x0+i, y0+cols)))
+		}
+	}
+}
+
+// Runs the jacobi method for the worker subproblem to get its partial result
+// Returns the number of iterations and the maximum diff of the whole problem, which are the same for every worker,
+// and whether it was stopped because the context was done
+func (worker worker[T]) solveSubproblem(ctx context.Context, resMat matrix.MatrixOf[T], problem Problem, problemSt stencil[T], opts options) (int, float64, bool) {
+	nIters, maxDiff, stop, matDef, w := 0, math.MaxFloat64, false, worker.matDef, T(opts.dampingFactor)
+	// Fields of the problem are decomposed like the matrix
+	x0, y0, st := matDef.Coords.X0, matDef.Coords.Y0, problemSt.submatrix(matDef)
 
 	// The algorithm requires computing each grid cell as a 3x3 filter with no corners
 	// Therefore, we need an aux matrix to keep the grid values in every iteration after computing new values
-	matA, matB := resMat.Clone(matDef).(matrix.Matrix), resMat.Clone(matDef).(matrix.Matrix)
+	matA, matB := resMat.Clone(matDef), resMat.Clone(matDef)
 
-	worker.setupBoundaries(initialValue, matrix.Hot, matrix.Cold, matrix.Hot, matrix.Hot)
+	worker.setupBoundaries(T(problem.InitialValue), problem.Boundaries)
 
-	for nIters := 0; maxDiff > tolerance && nIters < maxIters; nIters++ {
+	for maxDiff > opts.tolerance && nIters < opts.maxIters && !stop {
 		worker.sendOuterCells(matA)
 
 		// Outer cells are a special case which will be computed later on
-		for i := 1; i < matLen-1; i++ {
-			for j := 1; j < matLen-1; j++ {
+		for i := 1; i < matDef.Rows-1; i++ {
+			for j := 1; j < matDef.Cols-1; j++ {
 				// Compute new value with 3x3 filter with no corners
-				matB.SetCell(i, j, 0.2*(matA.GetCell(i, j)+matA.GetCell(i-1, j)+matA.GetCell(i+1, j)+matA.GetCell(i, j-1)+matA.GetCell(i, j+1)))
+				matB.SetCell(i, j, relax(matA.GetCell(i, j), st.newValue(x0+i, y0+j, matA.GetCell(i, j), matA.GetCell(i-1, j), matA.GetCell(i+1, j), matA.GetCell(i, j-1), matA.GetCell(i, j+1)), w))
 			}
 		}
 
 		worker.recvAdjacentCells(matA)
-		worker.computeOuterCells(matB, matA)
+		worker.updateGhostCells(matA, problem, st)
+		worker.computeOuterCells(matB, matA, st, w)
 		// Actual max diff is maximum of all threads maxDiff
-		maxDiff = worker.computeNewMaxDiff(matB, matA)
+		res := worker.computeNewMaxDiff(ctx, matB, matA)
+		maxDiff, stop = float64(res.value), res.stop
 
 		// Swap matrices
 		matA, matB = matB, matA
+		nIters++
+
+		if worker.id == 0 {
+			opts.notifyIteration(nIters, maxDiff)
+		}
 	}
 
 	worker.mergeSubproblem(resMat, matA)
+
+	return nIters, maxDiff, stop
 }
 
-func validatePreconditions(nDim, nThreads int) bool {
-	if nThreadsSqrt := int(math.Sqrt(float64(nThreads))); nThreadsSqrt*nThreadsSqrt == nThreads && nDim%nThreads == 0 {
-		return true
+// validatePreconditions checks the problem can be split into a square grid of submatrices of the same size, one per worker
+func validatePreconditions(rows, cols, nThreads int) error {
+	if nThreadsSqrt := int(math.Sqrt(float64(nThreads))); nThreadsSqrt*nThreadsSqrt != nThreads {
+		return ErrThreadsNotPerfectSquare
+	}
+	if rows%nThreads != 0 || cols%nThreads != 0 {
+		return ErrSizeNotDivisible
 	}
-	return false
+	return nil
 }
 
-// runMultithreadedJacobi runs a multi-threaded version of the jacobi method using Go routines
-func runMultithreadedJacobi(initialValue float64, nDim int, maxIters int, tolerance float64, nThreads int, matrixType matrix.MatrixType) (matrix.Matrix, int, float64) {
-	if !validatePreconditions(nDim, nThreads) {
-		os.Exit(invalidProblemParams)
-	}
+// subproblemSolver solves the subproblem of a worker, returning the same values as worker.solveSubproblem
+type subproblemSolver[T matrix.Float] func(worker worker[T], ctx context.Context, resMat matrix.MatrixOf[T], problem Problem, problemSt stencil[T], opts options) (int, float64, bool)
 
-	var resMat matrix.Matrix
-	if matrixType == matrix.OneDimMatrixType {
-		resMat = matrix.NewOneDimMatrix(initialValue, nDim+2, matrix.Hot, matrix.Cold, matrix.Hot, matrix.Hot)
-	} else {
-		resMat = matrix.NewTwoDimMatrix(initialValue, nDim+2, matrix.Hot, matrix.Cold, matrix.Hot, matrix.Hot, matrixType)
-	}
+// runMultithreadedJacobi runs a multi-threaded version of the jacobi method using Go routines, whose matrices cells are of type T
+// The problem parameters are expected to fulfill validatePreconditions
+// If the context is done, the workers stop at the end of the current iteration and the partial result is returned along with the context error
+func runMultithreadedJacobi[T matrix.Float](ctx context.Context, problem Problem, opts options) (matrix.MatrixOf[T], int, float64, error) {
+	return runWorkers(ctx, problem, opts, worker[T].solveSubproblem)
+}
+
+// runWorkers splits the problem into a square grid of submatrices, one per worker, which are solved concurrently by solve
+func runWorkers[T matrix.Float](ctx context.Context, problem Problem, opts options, solve subproblemSolver[T]) (matrix.MatrixOf[T], int, float64, error) {
+	nThreads := opts.nThreads
+	rows, cols := problem.size()
+	resMat, st := newMatrix[T](problem, opts.matrixType), newStencil[T](problem)
 
-	maxDiffResToRoot, maxDiffResFromRoot := make([]chan float64, nThreads), make([]chan float64, nThreads)
+	maxDiffResToRoot, maxDiffResFromRoot := make([]chan T, nThreads), make([]chan reduction[T], nThreads)
 	for i := 0; i < nThreads-1; i++ {
 		// These channels can also be unbuffered, as there's currently no computation between sending and receiving
-		maxDiffResToRoot[i] = make(chan float64, 1)
-		maxDiffResFromRoot[i] = make(chan float64, 1)
+		maxDiffResToRoot[i] = make(chan T, 1)
+		maxDiffResFromRoot[i] = make(chan reduction[T], 1)
 	}
-	subprobSize, nThreadsSqrt := int(math.Sqrt(float64(nDim*nDim/nThreads))), int(math.Sqrt(float64(nThreads)))
-	workerMatLen, adjacents := nDim/nThreadsSqrt, newAdjacents(nThreads, subprobSize)
+	nThreadsSqrt, conditions := int(math.Sqrt(float64(nThreads))), problem.Conditions
+	subprobRows, subprobCols := rows/nThreadsSqrt, cols/nThreadsSqrt
+	adjacents := newAdjacents[T](nThreads, subprobRows, subprobCols, conditions.Top.Kind == Periodic, conditions.Left.Kind == Periodic)
 
+	var nIters int
+	var maxDiff float64
+	var stopped bool
 	var wg sync.WaitGroup
 	wg.Add(nThreads)
 	for id := 0; id < nThreads; id++ {
-		x0, y0 := id/nThreadsSqrt*workerMatLen+1, id%nThreadsSqrt*workerMatLen+1
-		x1, y1 := x0+workerMatLen-1, y0+workerMatLen-1
+		x0, y0 := id/nThreadsSqrt*subprobRows+1, id%nThreadsSqrt*subprobCols+1
+		x1, y1 := x0+subprobRows-1, y0+subprobCols-1
+
+		go func(worker worker[T]) {
+			defer wg.Done()
 
-		go worker{
+			workerIters, workerMaxDiff, workerStopped := solve(worker, ctx, resMat, problem, st, opts)
+			// Every worker ends up with the same values, so it's enough to take them from the 'root' worker
+			if worker.id == 0 {
+				nIters, maxDiff, stopped = workerIters, workerMaxDiff, workerStopped
+			}
+		}(worker[T]{
 			id:           id,
 			rowNumber:    int(id / nThreadsSqrt),
 			columnNumber: id % nThreadsSqrt,
 			globalParams: globalParams{
 				nWorkers: nThreads,
-				size:     nDim,
+				rows:     rows,
+				cols:     cols,
 			},
 			matDef: matrix.MatrixDef{
-				Coords: matrix.Coords{x0, y0, x1, y1},
-				Size:   subprobSize,
+				Coords: matrix.Coords{X0: x0, Y0: y0, X1: x1, Y1: y1},
+				Rows:   subprobRows,
+				Cols:   subprobCols,
 			},
 			adjacents:          adjacents[id],
 			maxDiffResToRoot:   maxDiffResToRoot,
 			maxDiffResFromRoot: maxDiffResFromRoot,
-		}.solveSubproblem(resMat, initialValue, maxIters, tolerance, &wg)
+		})
 	}
 	wg.Wait()
 
-	// TODO: Return number of iterations and maximum diff
-	return resMat, 0, 0.0
+	// Leave the edges consistent with the resulting inner cells
+	updateGhostCells(problem, resMat, st)
+
+	if stopped {
+		return resMat, nIters, maxDiff, ctx.Err()
+	}
+	return resMat, nIters, maxDiff, nil
 }
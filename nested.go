@@ -0,0 +1,146 @@
+package jacobi
+
+import (
+	"context"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+)
+
+// fineRange retrieves the first and last indices of the cells of a finer matrix covered by the k-th cell of a coarser one,
+// which has n inner cells along the same direction. Edges cells only cover the edges cells of the finer matrix
+func fineRange(k, n int) (int, int) {
+	switch k {
+	case 0:
+		return 0, 0
+	case n + 1:
+		return 2*n + 1, 2*n + 1
+	default:
+		return 2*k - 1, 2 * k
+	}
+}
+
+// coarseBoundary is the boundary of a coarser matrix, whose cells take the mean of the cells they cover of the finer boundary
+type coarseBoundary struct {
+	fine matrix.Boundary
+}
+
+// Value retrieves the value of the k-th cell of an edge made of n cells, corners included
+func (boundary coarseBoundary) Value(k, n int) float64 {
+	first, last := fineRange(k, n-2)
+	return 0.5 * (boundary.fine.Value(first, 2*n-2) + boundary.fine.Value(last, 2*n-2))
+}
+
+// coarseMean computes the mean of the cells of a finer matrix covered by the (i, j) cell of a coarser one
+// with the given number of inner rows and columns, given the value of each finer cell
+func coarseMean(value func(i, j int) float64, i, j, rows, cols int) float64 {
+	iFirst, iLast := fineRange(i, rows)
+	jFirst, jLast := fineRange(j, cols)
+	return 0.25 * (value(iFirst, jFirst) + value(iFirst, jLast) + value(iLast, jFirst) + value(iLast, jLast))
+}
+
+// coarsenField returns the field of the coarsened problem with the given number of inner rows and columns, nil if the field isn't set
+func coarsenField(field matrix.Matrix, rows, cols int) matrix.Matrix {
+	if field == nil {
+		return nil
+	}
+
+	zero := matrix.ConstantBoundary(0.0)
+	coarse := matrix.NewRectangularOneDimMatrix(0.0, rows+2, cols+2, zero, zero, zero, zero)
+	for i := 0; i < rows+2; i++ {
+		for j := 0; j < cols+2; j++ {
+			coarse.SetCell(i, j, coarseMean(field.GetCell, i, j, rows, cols))
+		}
+	}
+
+	return coarse
+}
+
+// coarsened returns the problem on a grid with half the rows and columns and twice the spacing, whose boundaries, initial condition
+// and fields take the mean of the cells of the problem they cover
+// The mask isn't kept, as the coarsened problem is only solved to get an initial guess of the problem
+func (problem Problem) coarsened() Problem {
+	rows, cols := problem.size()
+	rows, cols = rows/2, cols/2
+
+	coarse := problem
+	coarse.NDim, coarse.Rows, coarse.Cols, coarse.Spacing = 0, rows, cols, 2*problem.spacing()
+	coarse.Boundaries = Boundaries{
+		Top:    coarseBoundary{problem.Boundaries.Top},
+		Bottom: coarseBoundary{problem.Boundaries.Bottom},
+		Left:   coarseBoundary{problem.Boundaries.Left},
+		Right:  coarseBoundary{problem.Boundaries.Right},
+	}
+	coarse.InitialMatrix, coarse.InitialCondition, coarse.Mask = nil, nil, nil
+	if initialCondition := problem.initialCondition(); initialCondition != nil {
+		coarse.InitialCondition = func(i, j int) float64 {
+			return coarseMean(initialCondition, i, j, rows, cols)
+		}
+	}
+	coarse.Source = coarsenField(problem.Source, rows, cols)
+	coarse.Conductivity = coarsenField(problem.Conductivity, rows, cols)
+	coarse.ConductivityX = coarsenField(problem.ConductivityX, rows, cols)
+	coarse.ConductivityY = coarsenField(problem.ConductivityY, rows, cols)
+
+	return coarse
+}
+
+// prolongated returns the initial condition of a problem given the solution of its coarsened problem
+// Void cells keep their own initial value, as the coarsened problem has no mask and they never change
+func prolongated[T matrix.Float](coarse matrix.MatrixOf[T], problem Problem, matrixType matrix.MatrixType) InitialCondition {
+	fine := newMatrix[T](problem.correctionProblem(nil), matrixType)
+	matrix.Prolongate(coarse, fine)
+	initialCondition := problem.initialCondition()
+
+	return func(i, j int) float64 {
+		if problem.Mask != nil && problem.Mask[i][j].Kind == VoidCell {
+			if initialCondition == nil {
+				return problem.InitialValue
+			}
+			return initialCondition(i, j)
+		}
+		return float64(fine.GetCell(i, j))
+	}
+}
+
+// runNestedJacobi runs the jacobi method on the problem coarsened into several levels, from the coarsest to the finest one,
+// each level starting from the prolongated solution of the coarser one, either single-threaded or multi-threaded
+// Coarse levels which can't be split among the threads are solved by a single one. Each level can take up to the maximum number
+// of iterations, and only the iterations of the finest one are notified
+// If the context is done, the rest of the levels are still prolongated, so that the partial result has the size of the problem, but they're barely solved:
+// single-threaded levels don't iterate at all, and multi-threaded ones stop at the end of their first iteration
+func runNestedJacobi[T matrix.Float](ctx context.Context, problem Problem, opts options) (ResultOf[T], error) {
+	var res ResultOf[T]
+
+	nLevels := opts.levels
+	if nLevels == 0 {
+		nLevels = maxLevels(problem.size())
+	}
+	problems := make([]Problem, nLevels)
+	problems[0] = problem
+	for l := 1; l < nLevels; l++ {
+		problems[l] = problems[l-1].coarsened()
+	}
+
+	var err error
+	res.LevelIterations = make([]int, nLevels)
+	for l := nLevels - 1; l >= 0; l-- {
+		levelProblem, levelOpts := problems[l], opts
+		if res.Matrix != nil {
+			levelProblem.InitialMatrix, levelProblem.InitialCondition = nil, prolongated(res.Matrix, levelProblem, opts.matrixType)
+		}
+		if l > 0 {
+			levelOpts.onIteration = nil
+		}
+		if rows, cols := levelProblem.size(); validatePreconditions(rows, cols, opts.nThreads) != nil {
+			levelOpts.nThreads = 1
+		}
+
+		var levelErr error
+		res.Matrix, res.LevelIterations[nLevels-1-l], res.MaxDiff, levelErr = runJacobi[T](ctx, levelProblem, levelOpts)
+		if err == nil {
+			err = levelErr
+		}
+	}
+	res.Iterations = res.LevelIterations[nLevels-1]
+
+	return res, err
+}
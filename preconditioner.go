@@ -0,0 +1,115 @@
+package jacobi
+
+import (
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+)
+
+// Preconditioner approximately solves, on every iteration of the conjugate gradient method, the correction problem of the solution:
+// the problem without heat source and with homogeneous edges, whose residual is the difference between a jacobi sweep of the solution
+// and the solution itself. The closer the approximation, the fewer iterations the conjugate gradient method takes
+type Preconditioner interface {
+	// Setup is called once before solving the problem with its correction problem, whose residual is the given matrix. It returns the function
+	// which sets the inner cells of the correction, whose cells are zero, to the approximate solution for the current values of the residual
+	// The correction problem can be solved like any other problem, e.g. by a few iterations of a Solver
+	Setup(problem Problem, residual matrix.Matrix) (func(correction matrix.Matrix), error)
+}
+
+// DiagonalPreconditioner is the jacobi preconditioner: a jacobi sweep of the correction problem starting from no correction,
+// which divides the residual of each cell by its own weight in the linear system
+type DiagonalPreconditioner struct{}
+
+// Setup returns the function applying the preconditioner to the residual
+func (DiagonalPreconditioner) Setup(problem Problem, residual matrix.Matrix) (func(correction matrix.Matrix), error) {
+	rows, cols := problem.size()
+
+	return func(correction matrix.Matrix) {
+		for i := 1; i <= rows; i++ {
+			for j := 1; j <= cols; j++ {
+				correction.SetCell(i, j, residual.GetCell(i, j))
+			}
+		}
+	}, nil
+}
+
+// SSORPreconditioner is the symmetric SOR preconditioner: a SOR sweep of the correction problem starting from no correction,
+// followed by another one in the reverse order
+type SSORPreconditioner struct {
+	// RelaxationFactor of both sweeps, which must be positive and lower than 2.5. If it isn't set, it's 1.0, i.e. symmetric Gauss-Seidel
+	RelaxationFactor float64
+}
+
+// Setup returns the function applying the preconditioner to the residual
+// An error is returned if the relaxation factor is out of range
+func (preconditioner SSORPreconditioner) Setup(problem Problem, residual matrix.Matrix) (func(correction matrix.Matrix), error) {
+	omega := preconditioner.RelaxationFactor
+	if omega == 0.0 {
+		omega = 1.0
+	}
+	if omega < 0 || omega >= maxRelaxationFactor {
+		return nil, ErrRelaxationFactorOutOfRange
+	}
+	rows, cols := problem.size()
+	st := newStencil[float64](problem)
+
+	sweep := func(mat matrix.Matrix, i, j int) {
+		mat.SetCell(i, j, relax(mat.GetCell(i, j), st.newValue(i, j, mat.GetCell(i, j), mat.GetCell(i-1, j), mat.GetCell(i+1, j), mat.GetCell(i, j-1), mat.GetCell(i, j+1)), omega))
+	}
+
+	return func(correction matrix.Matrix) {
+		updateGhostCells(problem, correction, st)
+		for i := 1; i <= rows; i++ {
+			for j := 1; j <= cols; j++ {
+				sweep(correction, i, j)
+			}
+		}
+
+		updateGhostCells(problem, correction, st)
+		for i := rows; i >= 1; i-- {
+			for j := cols; j >= 1; j-- {
+				sweep(correction, i, j)
+			}
+		}
+	}, nil
+}
+
+// MultigridPreconditioner is a single cycle of the multigrid method on the correction problem, starting from no correction
+// Like MultigridMethod, it doesn't support masks nor conductivity fields
+type MultigridPreconditioner struct {
+	// Cycle visiting the levels, VCycle if it isn't set
+	Cycle Cycle
+	// Levels, the finest one included. If it isn't set, the problem is coarsened as many times as possible
+	Levels int
+}
+
+// Setup returns the function applying the preconditioner to the residual
+// An error is returned if the multigrid method can't solve the problem with the given number of levels
+func (preconditioner MultigridPreconditioner) Setup(problem Problem, residual matrix.Matrix) (func(correction matrix.Matrix), error) {
+	if preconditioner.Levels < 0 {
+		return nil, ErrNegativeLevels
+	}
+	if err := validateMultigrid(problem, preconditioner.Levels); err != nil {
+		return nil, err
+	}
+	rows, cols := problem.size()
+
+	// Levels are kept from one call to the next, as the residual of the finest one is updated in place
+	opts := options{matrixType: matrix.OneDimMatrixType, dampingFactor: defaultDampingFactor, cycle: preconditioner.Cycle, levels: preconditioner.Levels}
+	levels := newLevels[float64](problem, opts)
+	finest := levels[0]
+
+	return func(correction matrix.Matrix) {
+		for i := 1; i <= rows; i++ {
+			for j := 1; j <= cols; j++ {
+				finest.mat.SetCell(i, j, 0.0)
+			}
+		}
+
+		cycle(levels, 0, opts)
+
+		for i := 1; i <= rows; i++ {
+			for j := 1; j <= cols; j++ {
+				correction.SetCell(i, j, finest.mat.GetCell(i, j))
+			}
+		}
+	}, nil
+}
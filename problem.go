@@ -0,0 +1,324 @@
+package jacobi
+
+import (
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+)
+
+// Boundaries defines the values of the four edges surrounding the simulated 2D space
+// The top and bottom edges are made of cols+2 cells and the left and right ones of rows+2 cells, as corners are included
+type Boundaries struct {
+	Top, Bottom, Left, Right matrix.Boundary
+}
+
+// DefaultBoundaries are the boundaries used by RunJacobi: every edge is hot except for the bottom one
+var DefaultBoundaries = Boundaries{
+	Top:    matrix.ConstantBoundary(matrix.Hot),
+	Bottom: matrix.ConstantBoundary(matrix.Cold),
+	Left:   matrix.ConstantBoundary(matrix.Hot),
+	Right:  matrix.ConstantBoundary(matrix.Hot),
+}
+
+// validate checks every edge is defined for the given number of rows and columns of the matrix
+func (boundaries Boundaries) validate(rows, cols int) error {
+	edges := []struct {
+		boundary matrix.Boundary
+		n        int
+	}{{boundaries.Top, cols}, {boundaries.Bottom, cols}, {boundaries.Left, rows}, {boundaries.Right, rows}}
+
+	for _, edge := range edges {
+		if edge.boundary == nil {
+			return ErrMissingBoundary
+		}
+		if values, ok := edge.boundary.(matrix.ValuesBoundary); ok && len(values) != edge.n {
+			return ErrBoundaryLength
+		}
+	}
+	return nil
+}
+
+// Problem describes a thermal transmission problem in a 2D space
+type Problem struct {
+	// InitialValue is the initial value of every inner cell
+	InitialValue float64
+	// InitialCondition is the initial value of each inner cell, which takes precedence over InitialValue if it's set
+	InitialCondition InitialCondition
+	// InitialMatrix seeds the inner cells with the ones of an existing matrix, e.g. a previous solution to warm-start from,
+	// and takes precedence over InitialCondition and InitialValue if it's set
+	// It has the same size as the problem matrix, hence cells are indexed the same way and edges cells are ignored
+	InitialMatrix matrix.Matrix
+	// NDim is the length of each side of the simulated space, not including the boundaries
+	NDim int
+	// Rows and Cols are the number of rows and columns of a rectangular simulated space, not including the boundaries
+	// If they're not set, the simulated space is a square whose side length is NDim
+	Rows, Cols int
+	// Boundaries are the values of the cells surrounding the simulated space
+	Boundaries Boundaries
+	// Conditions define how the cells surrounding the simulated space are computed, Dirichlet by default
+	Conditions BoundaryConditions
+	// Spacing is the distance between two adjacent cells, 1.0 if it's not set
+	Spacing float64
+	// Mask defines fixed and void inner cells, every inner cell is free if it's not set
+	Mask Mask
+	// Source is the heat generated (or absorbed, if negative) by each cell per unit of area, turning the problem into a Poisson equation
+	// It has the same size as the problem matrix, hence cells are indexed the same way and edges cells are ignored
+	Source matrix.Matrix
+	// Conductivity is the thermal conductivity of each cell, 1.0 for every cell if it's not set
+	// It has the same size as the problem matrix, hence cells are indexed the same way, and edges cells conductivity is used
+	// for computing the heat flowing through the edges, except for periodic edges, which take it from the opposite inner cells
+	Conductivity matrix.Matrix
+	// ConductivityX and ConductivityY are the thermal conductivity of each cell along the rows (horizontal) and the columns (vertical)
+	// of anisotropic materials, e.g. laminated ones. If set, they take precedence over Conductivity in their direction
+	ConductivityX, ConductivityY matrix.Matrix
+	// Anisotropy scales the conductivity of every cell along each direction, with no scaling along a direction whose factor isn't set
+	Anisotropy Anisotropy
+
+	// residual is added to the new value of every inner cell, turning the problem into the one solved by the correction
+	// of the iterative refinement. It has the same size as the problem matrix
+	residual matrix.Matrix
+}
+
+// InitialCondition defines the initial value of the inner cell in the (i, j) position of the problem matrix
+type InitialCondition func(i, j int) float64
+
+// Anisotropy defines the factors applied to the conductivity along the rows (X) and along the columns (Y)
+type Anisotropy struct {
+	X, Y float64
+}
+
+// factors retrieves the anisotropy factors, 1.0 along each direction whose factor isn't set
+func (anisotropy Anisotropy) factors() (float64, float64) {
+	factorX, factorY := anisotropy.X, anisotropy.Y
+	if factorX == 0 {
+		factorX = 1.0
+	}
+	if factorY == 0 {
+		factorY = 1.0
+	}
+	return factorX, factorY
+}
+
+// NewProblem creates a problem with the default boundaries
+func NewProblem(initialValue float64, nDim int) Problem {
+	return Problem{
+		InitialValue: initialValue,
+		NDim:         nDim,
+		Boundaries:   DefaultBoundaries,
+	}
+}
+
+// NewRectangularProblem creates a problem for a rectangular space with the default boundaries
+func NewRectangularProblem(initialValue float64, rows, cols int) Problem {
+	return Problem{
+		InitialValue: initialValue,
+		Rows:         rows,
+		Cols:         cols,
+		Boundaries:   DefaultBoundaries,
+	}
+}
+
+// size retrieves the number of rows and columns of the simulated space, not including the boundaries
+func (problem Problem) size() (int, int) {
+	if problem.Rows == 0 && problem.Cols == 0 {
+		return problem.NDim, problem.NDim
+	}
+	return problem.Rows, problem.Cols
+}
+
+// spacing retrieves the distance between two adjacent cells
+func (problem Problem) spacing() float64 {
+	if problem.Spacing == 0 {
+		return 1.0
+	}
+	return problem.Spacing
+}
+
+// conductivity retrieves the conductivity of the problem along each direction, with the edges cells of periodic edges
+// taking the conductivity of the inner cells next to the opposite edge
+func (problem Problem) conductivity() (matrix.Matrix, matrix.Matrix) {
+	conductivityX, conductivityY := problem.Conductivity, problem.Conductivity
+	if problem.ConductivityX != nil {
+		conductivityX = problem.ConductivityX
+	}
+	if problem.ConductivityY != nil {
+		conductivityY = problem.ConductivityY
+	}
+
+	return problem.wrapPeriodic(conductivityX), problem.wrapPeriodic(conductivityY)
+}
+
+// wrapPeriodic returns a copy of a field of the problem in which the edges cells of periodic edges
+// take the values of the inner cells next to the opposite edge
+func (problem Problem) wrapPeriodic(field matrix.Matrix) matrix.Matrix {
+	if field == nil || !problem.Conditions.isPeriodic() {
+		return field
+	}
+
+	rows, cols := problem.size()
+	rows, cols = rows+2, cols+2
+	wrapped := field.Clone(matrix.MatrixDef{
+		Coords: matrix.Coords{X0: 0, Y0: 0, X1: rows - 1, Y1: cols - 1},
+		Rows:   rows,
+		Cols:   cols,
+	})
+	if problem.Conditions.Top.Kind == Periodic {
+		for j := 1; j < cols-1; j++ {
+			wrapped.SetCell(0, j, wrapped.GetCell(rows-2, j))
+			wrapped.SetCell(rows-1, j, wrapped.GetCell(1, j))
+		}
+	}
+	if problem.Conditions.Left.Kind == Periodic {
+		for i := 1; i < rows-1; i++ {
+			wrapped.SetCell(i, 0, wrapped.GetCell(i, cols-2))
+			wrapped.SetCell(i, cols-1, wrapped.GetCell(i, 1))
+		}
+	}
+
+	return wrapped
+}
+
+// wrappedMask returns a copy of the mask of the problem in which the edges cells of periodic edges take the kind of the inner cells
+// next to the opposite edge, like wrapPeriodic does with fields, and the rest of edges cells are free. It's nil if there's no mask
+func (problem Problem) wrappedMask() Mask {
+	if problem.Mask == nil {
+		return nil
+	}
+
+	rows, cols := problem.size()
+	wrapped := NewRectangularMask(rows, cols)
+	for i := 1; i <= rows; i++ {
+		copy(wrapped[i][1:cols+1], problem.Mask[i][1:cols+1])
+	}
+	if problem.Conditions.Top.Kind == Periodic {
+		for j := 1; j <= cols; j++ {
+			wrapped[0][j].Kind, wrapped[rows+1][j].Kind = wrapped[rows][j].Kind, wrapped[1][j].Kind
+		}
+	}
+	if problem.Conditions.Left.Kind == Periodic {
+		for i := 1; i <= rows; i++ {
+			wrapped[i][0].Kind, wrapped[i][cols+1].Kind = wrapped[i][cols].Kind, wrapped[i][1].Kind
+		}
+	}
+
+	return wrapped
+}
+
+// correctionProblem returns the problem solved by the correction of the iterative refinement given the residual of the current solution,
+// whose boundaries, fixed cells and heat source are homogeneous
+func (problem Problem) correctionProblem(residual matrix.Matrix) Problem {
+	zero := matrix.ConstantBoundary(0.0)
+
+	correction := problem
+	correction.InitialValue, correction.InitialCondition, correction.InitialMatrix = 0.0, nil, nil
+	correction.Boundaries = Boundaries{Top: zero, Bottom: zero, Left: zero, Right: zero}
+	correction.Source, correction.residual = nil, residual
+	if problem.Mask != nil {
+		correction.Mask = problem.Mask.homogeneous()
+	}
+
+	return correction
+}
+
+// coarseProblem returns the correction problem of a grid with half the rows and columns and twice the spacing,
+// whose residual is the restriction of the residual of the problem
+func (problem Problem) coarseProblem(residual matrix.Matrix) Problem {
+	rows, cols := problem.size()
+
+	coarse := problem.correctionProblem(residual)
+	coarse.NDim, coarse.Rows, coarse.Cols, coarse.Spacing = 0, rows/2, cols/2, 2*problem.spacing()
+
+	return coarse
+}
+
+// validate checks the problem is well defined
+func (problem Problem) validate() error {
+	rows, cols := problem.size()
+	if rows <= 0 || cols <= 0 {
+		return ErrNonPositiveSize
+	}
+	if problem.Spacing < 0 {
+		return ErrNegativeSpacing
+	}
+	if err := problem.Conditions.validate(); err != nil {
+		return err
+	}
+	if problem.Mask != nil {
+		if err := problem.Mask.validate(rows+2, cols+2); err != nil {
+			return err
+		}
+	}
+	if problem.InitialMatrix != nil && (problem.InitialMatrix.GetRows() != rows+2 || problem.InitialMatrix.GetCols() != cols+2) {
+		return ErrInitialMatrixSize
+	}
+	if problem.Source != nil && (problem.Source.GetRows() != rows+2 || problem.Source.GetCols() != cols+2) {
+		return ErrSourceSize
+	}
+	for _, conductivity := range []matrix.Matrix{problem.Conductivity, problem.ConductivityX, problem.ConductivityY} {
+		if err := validateConductivity(conductivity, rows+2, cols+2); err != nil {
+			return err
+		}
+	}
+	if factorX, factorY := problem.Anisotropy.factors(); factorX <= 0 || factorY <= 0 {
+		return ErrNonPositiveConductivity
+	}
+	return problem.Boundaries.validate(rows+2, cols+2)
+}
+
+// initialCondition retrieves the initial value of each inner cell, nil if every inner cell takes InitialValue
+func (problem Problem) initialCondition() InitialCondition {
+	if problem.InitialMatrix != nil {
+		return problem.InitialMatrix.GetCell
+	}
+	return problem.InitialCondition
+}
+
+// newMatrix creates the matrix representing the problem, including its boundaries, initial condition and fixed cells
+func newMatrix[T matrix.Float](problem Problem, matrixType matrix.MatrixType) matrix.MatrixOf[T] {
+	var mat matrix.MatrixOf[T]
+	b := problem.Boundaries
+	rows, cols := problem.size()
+
+	if matrixType == matrix.OneDimMatrixType {
+		mat = matrix.NewRectangularOneDimMatrix(T(problem.InitialValue), rows+2, cols+2, b.Top, b.Bottom, b.Left, b.Right)
+	} else {
+		mat = matrix.NewRectangularTwoDimMatrix(T(problem.InitialValue), rows+2, cols+2, b.Top, b.Bottom, b.Left, b.Right, matrixType)
+	}
+
+	if initialCondition := problem.initialCondition(); initialCondition != nil {
+		for i := 1; i <= rows; i++ {
+			for j := 1; j <= cols; j++ {
+				mat.SetCell(i, j, T(initialCondition(i, j)))
+			}
+		}
+	}
+
+	if problem.Mask != nil {
+		for i := 1; i <= rows; i++ {
+			for j := 1; j <= cols; j++ {
+				if problem.Mask[i][j].Kind == FixedCell {
+					mat.SetCell(i, j, T(problem.Mask[i][j].Value))
+				}
+			}
+		}
+	}
+
+	return mat
+}
+
+// validateConductivity checks a conductivity field, if set, matches the size of the problem matrix and is positive
+func validateConductivity(conductivity matrix.Matrix, rows, cols int) error {
+	if conductivity == nil {
+		return nil
+	}
+
+	if conductivity.GetRows() != rows || conductivity.GetCols() != cols {
+		return ErrConductivitySize
+	}
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if conductivity.GetCell(i, j) <= 0 {
+				return ErrNonPositiveConductivity
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package jacobi
+
+import (
+	"context"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"math"
+)
+
+// Cells are colored like a chessboard depending on the parity of their global coordinates, so that
+// the adjacent cells of a red cell are black and vice versa
+const (
+	redCells = iota
+	blackCells
+)
+
+// runRedBlackGaussSeidel runs the red-black Gauss-Seidel method, whose matrices cells are of type T
+// Each iteration updates in place all the red cells first and then all the black ones, which only depend on cells of the other color,
+// so every half-sweep can be split among several threads
+// It's also the red-black SOR method, as the cells are relaxed by the relaxation factor of the options
+func runRedBlackGaussSeidel[T matrix.Float](ctx context.Context, problem Problem, opts options) (matrix.MatrixOf[T], int, float64, error) {
+	if opts.nThreads == 1 {
+		return runSinglethreadedRedBlackGaussSeidel[T](ctx, problem, opts)
+	}
+	return runWorkers(ctx, problem, opts, worker[T].solveRedBlackSubproblem)
+}
+
+// runSinglethreadedRedBlackGaussSeidel runs a single-threaded version of the red-black Gauss-Seidel method, whose matrices cells are of type T
+// If the context is done, it stops at the end of the current iteration and the partial result is returned along with the context error
+func runSinglethreadedRedBlackGaussSeidel[T matrix.Float](ctx context.Context, problem Problem, opts options) (matrix.MatrixOf[T], int, float64, error) {
+	rows, cols := problem.size()
+
+	// A single matrix is needed, as cells are updated in place
+	mat := newMatrix[T](problem, opts.matrixType)
+
+	nIters, maxDiff, st, omega := 0, math.MaxFloat64, newStencil[T](problem), T(opts.relaxationFactor)
+	var err error
+
+	for maxDiff > opts.tolerance && nIters < opts.maxIters {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		iterMaxDiff := T(0.0)
+		for color := redCells; color <= blackCells; color++ {
+			// Edges depending on the inner cells must see the cells updated by the previous half-sweep
+			updateGhostCells(problem, mat, st)
+
+			for i := 1; i <= rows; i++ {
+				for j := 1 + (i+1+color)%2; j <= cols; j += 2 {
+					// Compute new value with 3x3 filter with no corners
+					prevValue := mat.GetCell(i, j)
+					mat.SetCell(i, j, relax(prevValue, st.newValue(i, j, prevValue, mat.GetCell(i-1, j), mat.GetCell(i+1, j), mat.GetCell(i, j-1), mat.GetCell(i, j+1)), omega))
+					iterMaxDiff = max(iterMaxDiff, utils.Abs(prevValue-mat.GetCell(i, j)))
+				}
+			}
+		}
+
+		maxDiff = float64(iterMaxDiff)
+		nIters++
+		opts.notifyIteration(nIters, maxDiff)
+	}
+
+	// Leave the edges consistent with the resulting inner cells
+	updateGhostCells(problem, mat, st)
+
+	return mat, nIters, maxDiff, err
+}
+
+// Updates in place the cells of the given color of this worker submatrix, relaxed by omega, and returns the maximum diff
+func (worker worker[T]) sweepCells(mat matrix.MatrixOf[T], st stencil[T], color int, omega T) T {
+	x0, y0, maxDiff := worker.matDef.Coords.X0, worker.matDef.Coords.Y0, T(0.0)
+
+	for i := 0; i < worker.matDef.Rows; i++ {
+		for j := (x0 + i + y0 + color) % 2; j < worker.matDef.Cols; j += 2 {
+			// Compute new value with 3x3 filter with no corners
+			prevValue := mat.GetCell(i, j)
+			mat.SetCell(i, j, relax(prevValue, st.newValue(x0+i, y0+j, prevValue, worker.getCell(mat, i-1, j), worker.getCell(mat, i+1, j), worker.getCell(mat, i, j-1), worker.getCell(mat, i, j+1)), omega))
+			maxDiff = max(maxDiff, utils.Abs(prevValue-mat.GetCell(i, j)))
+		}
+	}
+
+	return maxDiff
+}
+
+// Runs the red-black Gauss-Seidel method for the worker subproblem to get its partial result
+// Outer cells are exchanged with the adjacent workers before each half-sweep, i.e. twice per iteration
+// Returns the same values as solveSubproblem
+func (worker worker[T]) solveRedBlackSubproblem(ctx context.Context, resMat matrix.MatrixOf[T], problem Problem, problemSt stencil[T], opts options) (int, float64, bool) {
+	nIters, maxDiff, stop, matDef := 0, math.MaxFloat64, false, worker.matDef
+	// Fields of the problem are decomposed like the matrix
+	st, mat := problemSt.submatrix(matDef), resMat.Clone(matDef)
+
+	worker.setupBoundaries(T(problem.InitialValue), problem.Boundaries)
+
+	for maxDiff > opts.tolerance && nIters < opts.maxIters && !stop {
+		iterMaxDiff := T(0.0)
+		for color := redCells; color <= blackCells; color++ {
+			worker.sendOuterCells(mat)
+			worker.recvAdjacentCells(mat)
+			worker.updateGhostCells(mat, problem, st)
+			iterMaxDiff = max(iterMaxDiff, worker.sweepCells(mat, st, color, T(opts.relaxationFactor)))
+		}
+
+		// Actual max diff is maximum of all threads maxDiff
+		res := worker.maxReduce(ctx, iterMaxDiff)
+		maxDiff, stop = float64(res.value), res.stop
+		nIters++
+
+		if worker.id == 0 {
+			opts.notifyIteration(nIters, maxDiff)
+		}
+	}
+
+	worker.mergeSubproblem(resMat, mat)
+
+	return nIters, maxDiff, stop
+}
@@ -1,42 +1,53 @@
 package jacobi
 
 import (
+	"context"
 	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
 	"math"
 )
 
-// runSinglethreadedJacobi runs a single-threaded version of the jacobi method
-func runSinglethreadedJacobi(initialValue float64, nDim int, maxIters int, tolerance float64, matrixType matrix.MatrixType) (matrix.Matrix, int, float64) {
+// runSinglethreadedJacobi runs a single-threaded version of the jacobi method, whose matrices cells are of type T
+// If the context is done, it stops at the end of the current iteration and the partial result is returned along with the context error
+func runSinglethreadedJacobi[T matrix.Float](ctx context.Context, problem Problem, opts options) (matrix.MatrixOf[T], int, float64, error) {
+	rows, cols := problem.size()
+
 	// The algorithm requires computing each grid cell as a 3x3 filter with no corners
 	// Therefore, we need an aux matrix to keep the grid values in every iteration after computing new values
-	var matA matrix.Matrix
-	if matrixType == matrix.OneDimMatrixType {
-		matA = matrix.NewOneDimMatrix(initialValue, nDim+2, matrix.Hot, matrix.Cold, matrix.Hot, matrix.Hot)
-	} else {
-		matA = matrix.NewTwoDimMatrix(initialValue, nDim+2, matrix.Hot, matrix.Cold, matrix.Hot, matrix.Hot, matrixType)
-	}
+	matA := newMatrix[T](problem, opts.matrixType)
 	matB := matA.Clone(matrix.MatrixDef{
-		matrix.Coords{0, 0, nDim + 1, nDim + 1},
-		nDim + 2,
-	}).(matrix.Matrix)
+		Coords: matrix.Coords{X0: 0, Y0: 0, X1: rows + 1, Y1: cols + 1},
+		Rows:   rows + 2,
+		Cols:   cols + 2,
+	})
 
-	matrixIters, nIters, maxDiff := nDim+1, 0, math.MaxFloat64
+	nIters, maxDiff, st, w := 0, math.MaxFloat64, newStencil[T](problem), T(opts.dampingFactor)
+	var err error
 
-	for maxDiff > tolerance && nIters < maxIters {
-		maxDiff = 0.0
+	for maxDiff > opts.tolerance && nIters < opts.maxIters {
+		if err = ctx.Err(); err != nil {
+			break
+		}
 
-		for i := 1; i < matrixIters; i++ {
-			for j := 1; j < matrixIters; j++ {
+		iterMaxDiff := T(0.0)
+		updateGhostCells(problem, matA, st)
+
+		for i := 1; i <= rows; i++ {
+			for j := 1; j <= cols; j++ {
 				// Compute new value with 3x3 filter with no corners
-				matB.SetCell(i, j, 0.2*(matA.GetCell(i, j)+matA.GetCell(i-1, j)+matA.GetCell(i+1, j)+matA.GetCell(i, j-1)+matA.GetCell(i, j+1)))
-				maxDiff = math.Max(maxDiff, math.Abs(matA.GetCell(i, j)-matB.GetCell(i, j)))
+				matB.SetCell(i, j, relax(matA.GetCell(i, j), st.newValue(i, j, matA.GetCell(i, j), matA.GetCell(i-1, j), matA.GetCell(i+1, j), matA.GetCell(i, j-1), matA.GetCell(i, j+1)), w))
+				iterMaxDiff = max(iterMaxDiff, utils.Abs(matA.GetCell(i, j)-matB.GetCell(i, j)))
 			}
 		}
 
 		// Swap matrices
-		matA, matB = matB, matA
+		matA, matB, maxDiff = matB, matA, float64(iterMaxDiff)
 		nIters++
+		opts.notifyIteration(nIters, maxDiff)
 	}
 
-	return matA, nIters, maxDiff
+	// Leave the edges consistent with the resulting inner cells
+	updateGhostCells(problem, matA, st)
+
+	return matA, nIters, maxDiff, err
 }
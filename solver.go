@@ -0,0 +1,365 @@
+package jacobi
+
+import (
+	"context"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+)
+
+const (
+	defaultNThreads      = 1
+	defaultMatrixType    = matrix.TwoDimContiguousMatrixType
+	defaultMaxIters      = 1000
+	defaultTolerance     = 1.0e-4
+	defaultMethod        = JacobiMethod
+	defaultDampingFactor = 1.0
+)
+
+const (
+	// JacobiMethod sweeps the matrix with the jacobi method until it converges
+	JacobiMethod Method = iota
+	// MixedPrecisionMethod does bulk jacobi sweeps in single precision to solve for a correction of the solution,
+	// which is periodically refined by computing the residual in the precision of the solver matrices
+	// It reaches the same tolerance as JacobiMethod, even if it's beyond single precision
+	MixedPrecisionMethod
+	// GaussSeidelMethod sweeps the matrix updating the cells in place, which takes fewer iterations than JacobiMethod
+	// It's sequential, so it can only be run by a single thread
+	GaussSeidelMethod
+	// RedBlackGaussSeidelMethod is the Gauss-Seidel method updating all the red cells of a chessboard first and then the black ones,
+	// which can be run by multiple threads
+	RedBlackGaussSeidelMethod
+	// SORMethod is the Gauss-Seidel method moving every cell past its new value by a relaxation factor, which speeds up the convergence
+	// It's sequential, so it can only be run by a single thread
+	SORMethod
+	// RedBlackSORMethod is the red-black Gauss-Seidel method with a relaxation factor, which can be run by multiple threads
+	RedBlackSORMethod
+	// MultigridMethod smooths the solution with damped jacobi sweeps and corrects it with the solution of the same problem
+	// on coarser grids, which converges in a number of iterations almost independent of the size of the problem
+	// It can only be run by a single thread
+	MultigridMethod
+	// NestedIterationMethod runs the jacobi method on the problem coarsened into several levels, from the coarsest to the finest one,
+	// each one starting from the interpolated solution of the coarser one, which takes fewer iterations on the finest one than JacobiMethod
+	NestedIterationMethod
+	// ConjugateGradientMethod solves the linear system whose solution is the fixed point of the jacobi method by the conjugate gradient method,
+	// which takes a number of iterations proportional to the number of rows and columns instead of their square
+	// It can be preconditioned, in which case it can only be run by a single thread
+	ConjugateGradientMethod
+)
+
+const (
+	// VCycle visits each coarser level of the multigrid method once
+	VCycle Cycle = iota
+	// WCycle visits each coarser level of the multigrid method twice, which is more expensive but more robust
+	WCycle
+)
+
+// Method defines the iterative method used to solve a problem
+type Method int
+
+// ToString returns a string representation of a method
+func (method Method) ToString() string {
+	switch method {
+	case MixedPrecisionMethod:
+		return "Mixed precision jacobi"
+	case GaussSeidelMethod:
+		return "Gauss-Seidel"
+	case RedBlackGaussSeidelMethod:
+		return "Red-black Gauss-Seidel"
+	case SORMethod:
+		return "SOR"
+	case RedBlackSORMethod:
+		return "Red-black SOR"
+	case MultigridMethod:
+		return "Multigrid"
+	case NestedIterationMethod:
+		return "Nested iteration jacobi"
+	case ConjugateGradientMethod:
+		return "Conjugate gradient"
+	case JacobiMethod:
+		return "Jacobi"
+	default:
+		return "Unknown method"
+	}
+}
+
+// Cycle defines the order in which the multigrid method visits the levels
+type Cycle int
+
+// ToString returns a string representation of a cycle
+func (cycle Cycle) ToString() string {
+	switch cycle {
+	case WCycle:
+		return "W-cycle"
+	default:
+		return "V-cycle"
+	}
+}
+
+// IterationCallback is called after every iteration with the number of iterations done so far
+// and the maximum difference between the last two iterations
+type IterationCallback func(nIters int, maxDiff float64)
+
+// options holds the configuration of a Solver
+type options struct {
+	nThreads   int
+	matrixType matrix.MatrixType
+	maxIters   int
+	tolerance  float64
+	method     Method
+	// Zero unless set, in which case the optimal one is used
+	relaxationFactor float64
+	dampingFactor    float64
+	cycle            Cycle
+	// Zero unless set, in which case as many levels as possible are used
+	levels int
+	// Nil unless set, in which case the conjugate gradient method isn't preconditioned
+	preconditioner Preconditioner
+	onIteration    IterationCallback
+}
+
+// Option configures a Solver
+type Option func(*options)
+
+// WithThreads sets the number of Go routines used to solve the problem
+func WithThreads(nThreads int) Option {
+	return func(opts *options) {
+		opts.nThreads = nThreads
+	}
+}
+
+// WithMatrixType sets the underlying representation of the matrices
+func WithMatrixType(matrixType matrix.MatrixType) Option {
+	return func(opts *options) {
+		opts.matrixType = matrixType
+	}
+}
+
+// WithMaxIters sets the maximum number of iterations
+func WithMaxIters(maxIters int) Option {
+	return func(opts *options) {
+		opts.maxIters = maxIters
+	}
+}
+
+// WithTolerance sets the maximum difference between two iterations under which the simulation is considered converged
+func WithTolerance(tolerance float64) Option {
+	return func(opts *options) {
+		opts.tolerance = tolerance
+	}
+}
+
+// WithMethod sets the iterative method used to solve the problem
+func WithMethod(method Method) Option {
+	return func(opts *options) {
+		opts.method = method
+	}
+}
+
+// WithRelaxationFactor sets the relaxation factor of SORMethod and RedBlackSORMethod, which must be positive and lower than 2.5
+// A factor of 1.0 is the Gauss-Seidel method. If it isn't set, the optimal factor for the size of the problem is used
+func WithRelaxationFactor(omega float64) Option {
+	return func(opts *options) {
+		opts.relaxationFactor = omega
+	}
+}
+
+// WithDampingFactor sets the damping factor w of the jacobi method, whose new values become (1-w)*previous value + w*new value,
+// which must be positive and lower than 1.25, as the damped jacobi method diverges beyond it
+// It's also used by the single precision sweeps of MixedPrecisionMethod
+func WithDampingFactor(w float64) Option {
+	return func(opts *options) {
+		opts.dampingFactor = w
+	}
+}
+
+// WithCycle sets the cycle of MultigridMethod
+func WithCycle(cycle Cycle) Option {
+	return func(opts *options) {
+		opts.cycle = cycle
+	}
+}
+
+// WithLevels sets the number of levels of MultigridMethod and NestedIterationMethod, the finest one included, each one halving the rows and columns of the finer one
+// If it isn't set, the problem is coarsened as many times as possible
+func WithLevels(levels int) Option {
+	return func(opts *options) {
+		opts.levels = levels
+	}
+}
+
+// WithPreconditioner sets the preconditioner of ConjugateGradientMethod, which can then only be run by a single thread
+func WithPreconditioner(preconditioner Preconditioner) Option {
+	return func(opts *options) {
+		opts.preconditioner = preconditioner
+	}
+}
+
+// WithIterationCallback sets a function to be called after every iteration
+func WithIterationCallback(callback IterationCallback) Option {
+	return func(opts *options) {
+		opts.onIteration = callback
+	}
+}
+
+// ResultOf is the outcome of solving a problem with matrices whose cells are of type T
+type ResultOf[T matrix.Float] struct {
+	// Matrix is the resulting matrix, including the boundaries
+	Matrix matrix.MatrixOf[T]
+	// Iterations is the number of iterations done, only on the finest level for NestedIterationMethod
+	Iterations int
+	// MaxDiff is the maximum difference between the last two iterations
+	MaxDiff float64
+	// LowPrecisionSweeps and HighPrecisionSweeps are the number of single precision sweeps and residual computations
+	// done by MixedPrecisionMethod, which add up to the number of iterations. They're zero for any other method
+	LowPrecisionSweeps, HighPrecisionSweeps int
+	// RelaxationFactor is the relaxation factor used by the Gauss-Seidel and SOR methods, which is 1.0 for the Gauss-Seidel ones.
+	// It's zero for any other method
+	RelaxationFactor float64
+	// LevelIterations is the number of iterations done on each level by NestedIterationMethod, from the coarsest to the finest one.
+	// It's nil for any other method
+	LevelIterations []int
+}
+
+// Result is the outcome of solving a problem with double precision matrices
+type Result = ResultOf[float64]
+
+// Solver solves thermal transmission problems using the jacobi method
+type Solver struct {
+	opts options
+}
+
+// NewSolver creates a solver configured with the given options
+func NewSolver(opts ...Option) *Solver {
+	solver := &Solver{
+		opts: options{
+			nThreads:      defaultNThreads,
+			matrixType:    defaultMatrixType,
+			maxIters:      defaultMaxIters,
+			tolerance:     defaultTolerance,
+			method:        defaultMethod,
+			dampingFactor: defaultDampingFactor,
+		},
+	}
+	for _, opt := range opts {
+		opt(&solver.opts)
+	}
+
+	return solver
+}
+
+// Solve runs the jacobi method to simulate the thermal transmission described by the problem
+// An error is returned if either the problem or the solver options are invalid
+func (solver *Solver) Solve(problem Problem) (Result, error) {
+	return solver.SolveContext(context.Background(), problem)
+}
+
+// SolveContext is like Solve, but stops at the end of the current iteration once the context is done,
+// in which case the partial result is returned along with the context error
+func (solver *Solver) SolveContext(ctx context.Context, problem Problem) (Result, error) {
+	return SolveContextOf[float64](ctx, solver, problem)
+}
+
+// SolveOf is like Solver.Solve, but the matrices cells are of type T, e.g. float32 to halve the memory used by huge grids
+// The fields of the problem are kept in double precision
+func SolveOf[T matrix.Float](solver *Solver, problem Problem) (ResultOf[T], error) {
+	return SolveContextOf[T](context.Background(), solver, problem)
+}
+
+// SolveContextOf is like Solver.SolveContext, but the matrices cells are of type T
+func SolveContextOf[T matrix.Float](ctx context.Context, solver *Solver, problem Problem) (ResultOf[T], error) {
+	var res ResultOf[T]
+	var err error
+
+	if err := problem.validate(); err != nil {
+		return res, err
+	}
+	if err := solver.opts.validate(); err != nil {
+		return res, err
+	}
+
+	if solver.opts.nThreads > 1 {
+		rows, cols := problem.size()
+		if err := validatePreconditions(rows, cols, solver.opts.nThreads); err != nil {
+			return res, err
+		}
+	}
+	if solver.opts.method == MultigridMethod {
+		if err := validateMultigrid(problem, solver.opts.levels); err != nil {
+			return res, err
+		}
+	}
+	if solver.opts.method == NestedIterationMethod {
+		if err := validateLevels(problem, solver.opts.levels); err != nil {
+			return res, err
+		}
+	}
+
+	switch solver.opts.method {
+	case MixedPrecisionMethod:
+		res, err = runMixedPrecisionJacobi[T](ctx, problem, solver.opts)
+	case GaussSeidelMethod, SORMethod:
+		opts := solver.opts.relaxed(problem)
+		res.RelaxationFactor = opts.relaxationFactor
+		res.Matrix, res.Iterations, res.MaxDiff, err = runGaussSeidel[T](ctx, problem, opts)
+	case RedBlackGaussSeidelMethod, RedBlackSORMethod:
+		opts := solver.opts.relaxed(problem)
+		res.RelaxationFactor = opts.relaxationFactor
+		res.Matrix, res.Iterations, res.MaxDiff, err = runRedBlackGaussSeidel[T](ctx, problem, opts)
+	case MultigridMethod:
+		res.Matrix, res.Iterations, res.MaxDiff, err = runMultigrid[T](ctx, problem, solver.opts)
+	case NestedIterationMethod:
+		res, err = runNestedJacobi[T](ctx, problem, solver.opts)
+	case ConjugateGradientMethod:
+		res.Matrix, res.Iterations, res.MaxDiff, err = runConjugateGradient[T](ctx, problem, solver.opts)
+	default:
+		res.Matrix, res.Iterations, res.MaxDiff, err = runJacobi[T](ctx, problem, solver.opts)
+	}
+
+	return res, err
+}
+
+// runJacobi runs the single-threaded or the multi-threaded version of the jacobi method depending on the number of threads
+func runJacobi[T matrix.Float](ctx context.Context, problem Problem, opts options) (matrix.MatrixOf[T], int, float64, error) {
+	if opts.nThreads == 1 {
+		return runSinglethreadedJacobi[T](ctx, problem, opts)
+	}
+	return runMultithreadedJacobi[T](ctx, problem, opts)
+}
+
+// validate checks the options are valid regardless of the problem
+func (opts options) validate() error {
+	if opts.nThreads <= 0 {
+		return ErrNonPositiveThreads
+	}
+	if opts.maxIters <= 0 {
+		return ErrNonPositiveMaxIters
+	}
+	if opts.tolerance <= 0 {
+		return ErrNonPositiveTolerance
+	}
+	if opts.method < JacobiMethod || opts.method > ConjugateGradientMethod {
+		return ErrUnknownMethod
+	}
+	if (opts.method == GaussSeidelMethod || opts.method == SORMethod || opts.method == MultigridMethod) && opts.nThreads > 1 {
+		return ErrSequentialMethod
+	}
+	if opts.method == ConjugateGradientMethod && opts.preconditioner != nil && opts.nThreads > 1 {
+		return ErrSequentialMethod
+	}
+	if opts.levels < 0 {
+		return ErrNegativeLevels
+	}
+	if opts.dampingFactor <= 0 || opts.dampingFactor >= maxDampingFactor {
+		return ErrDampingFactorOutOfRange
+	}
+	if opts.relaxationFactor < 0 || opts.relaxationFactor >= maxRelaxationFactor {
+		return ErrRelaxationFactorOutOfRange
+	}
+	return nil
+}
+
+// notifyIteration calls the iteration callback, if any
+func (opts options) notifyIteration(nIters int, maxDiff float64) {
+	if opts.onIteration != nil {
+		opts.onIteration(nIters, maxDiff)
+	}
+}
@@ -0,0 +1,46 @@
+package jacobi
+
+import (
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"math"
+)
+
+// maxRelaxationFactor is the upper limit of the relaxation factor
+// The new value of a cell already keeps a fifth of its current value, so the usual limit of 2 is scaled by 1/0.8
+const maxRelaxationFactor = 2.5
+
+// optimalRelaxationFactor computes the relaxation factor which makes the SOR method converge the fastest for the Laplace problem
+// on a rectangle of the given size, with uniform conductivity and fixed edges
+func optimalRelaxationFactor(rows, cols int) float64 {
+	// Spectral radius of the jacobi method without the current value of the cell
+	rho := (math.Cos(math.Pi/float64(rows+1)) + math.Cos(math.Pi/float64(cols+1))) / 2
+	// The optimal factor of the textbook SOR method, which relaxes the mean of the adjacent cells, is scaled like maxRelaxationFactor
+	return 2 / (1 + math.Sqrt(1-rho*rho)) / 0.8
+}
+
+// relaxed returns the options with the relaxation factor used by the method: 1.0 for the Gauss-Seidel methods,
+// and the optimal one for the SOR methods unless it was set
+func (opts options) relaxed(problem Problem) options {
+	switch {
+	case opts.method == GaussSeidelMethod || opts.method == RedBlackGaussSeidelMethod:
+		opts.relaxationFactor = 1.0
+	case opts.relaxationFactor == 0.0:
+		opts.relaxationFactor = optimalRelaxationFactor(problem.size())
+	}
+	return opts
+}
+
+// maxDampingFactor is the upper limit of the damping factor
+// The new value of a cell already keeps a fifth of its current value, so the error component alternating between adjacent cells
+// is multiplied by 1-1.6*w on every sweep, which only shrinks if w is lower than 1.25
+const maxDampingFactor = 1.25
+
+// relax moves the value of a cell from its previous value towards the new one by the factor omega, i.e. (1-omega)*prevValue + omega*newValue
+// It's the relaxation factor of the SOR methods and the damping factor of the jacobi method
+func relax[T matrix.Float](prevValue, newValue, omega T) T {
+	// Keep the undamped jacobi method and the Gauss-Seidel methods exact
+	if omega == 1.0 {
+		return newValue
+	}
+	return prevValue + omega*(newValue-prevValue)
+}
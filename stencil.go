@@ -0,0 +1,145 @@
+package jacobi
+
+import (
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+)
+
+// stencil computes the new value of the inner cells of the problem, whose values are of type T
+// Fields of the problem are kept in double precision regardless of T
+type stencil[T matrix.Float] struct {
+	// Optional, nil if every cell is free. Edges cells of periodic edges are wrapped
+	mask Mask
+	// Optional fields of the problem, nil if there's no heat source or the conductivity along a direction is uniform
+	// They may be a submatrix of the problem fields, whose top-left corner is in the (x0, y0) position
+	source, conductivityX, conductivityY matrix.Matrix
+	// Optional, nil unless the stencil belongs to the correction problem of the iterative refinement
+	residual matrix.Matrix
+	x0, y0   int
+	// Factor applied to the source, squared spacing
+	sourceFactor float64
+	// Factors applied to the conductivity along each direction
+	factorX, factorY float64
+	// Whether the conductivity is 1.0 in every cell along both directions
+	isUniform bool
+}
+
+// newStencil creates the stencil of a problem
+func newStencil[T matrix.Float](problem Problem) stencil[T] {
+	spacing := problem.spacing()
+	conductivityX, conductivityY := problem.conductivity()
+	factorX, factorY := problem.Anisotropy.factors()
+
+	return stencil[T]{
+		mask:          problem.wrappedMask(),
+		source:        problem.Source,
+		residual:      problem.residual,
+		conductivityX: conductivityX,
+		conductivityY: conductivityY,
+		sourceFactor:  spacing * spacing,
+		factorX:       factorX,
+		factorY:       factorY,
+		isUniform:     conductivityX == nil && conductivityY == nil && factorX == 1.0 && factorY == 1.0,
+	}
+}
+
+// submatrix returns a stencil whose fields are decomposed to only contain the submatrix defined by matDef and its adjacent cells
+// The conductivity of the cells adjacent to the submatrix is needed to compute its outer cells. As it doesn't change,
+// it's taken once at this point instead of being shared among workers on every iteration
+func (st stencil[T]) submatrix(matDef matrix.MatrixDef) stencil[T] {
+	coords := matDef.Coords
+	haloDef := matrix.MatrixDef{
+		Coords: matrix.Coords{X0: coords.X0 - 1, Y0: coords.Y0 - 1, X1: coords.X1 + 1, Y1: coords.Y1 + 1},
+		Rows:   matDef.Rows + 2,
+		Cols:   matDef.Cols + 2,
+	}
+
+	if st.source != nil {
+		st.source = st.source.Clone(haloDef)
+	}
+	if st.residual != nil {
+		st.residual = st.residual.Clone(haloDef)
+	}
+	if st.conductivityX != nil {
+		st.conductivityX = st.conductivityX.Clone(haloDef)
+	}
+	if st.conductivityY != nil {
+		st.conductivityY = st.conductivityY.Clone(haloDef)
+	}
+	st.x0, st.y0 = haloDef.Coords.X0, haloDef.Coords.Y0
+
+	return st
+}
+
+// sourceValue retrieves the heat source of the inner cell in the (i, j) position of the problem matrix
+func (st stencil[T]) sourceValue(i, j int) T {
+	if st.source == nil {
+		return 0.0
+	}
+	return T(st.sourceFactor * st.source.GetCell(i-st.x0, j-st.y0))
+}
+
+// residualValue retrieves the residual of the inner cell in the (i, j) position of the problem matrix, which is added to its new value
+func (st stencil[T]) residualValue(i, j int) T {
+	if st.residual == nil {
+		return 0.0
+	}
+	return T(st.residual.GetCell(i-st.x0, j-st.y0))
+}
+
+// faceConductivity retrieves the conductivity between two adjacent cells of the problem matrix,
+// which is the harmonic mean of both cells conductivity along the direction joining them
+func (st stencil[T]) faceConductivity(iA, jA, iB, jB int) float64 {
+	// Cells in the same row are joined along the X direction
+	conductivity, factor := st.conductivityY, st.factorY
+	if iA == iB {
+		conductivity, factor = st.conductivityX, st.factorX
+	}
+	if conductivity == nil {
+		return factor
+	}
+
+	kA, kB := conductivity.GetCell(iA-st.x0, jA-st.y0), conductivity.GetCell(iB-st.x0, jB-st.y0)
+	return factor * 2 * kA * kB / (kA + kB)
+}
+
+// newValue computes the new value of the inner cell in the (i, j) position, given its value and its adjacent cells values
+// Positions are the ones of the problem matrix
+func (st stencil[T]) newValue(i, j int, center, top, bottom, left, right T) T {
+	if st.mask != nil {
+		if st.mask[i][j].Kind != FreeCell {
+			return center
+		}
+		// Void cells don't conduct heat, like an insulated edge
+		if st.mask.isVoid(i-1, j) {
+			top = center
+		}
+		if st.mask.isVoid(i+1, j) {
+			bottom = center
+		}
+		if st.mask.isVoid(i, j-1) {
+			left = center
+		}
+		if st.mask.isVoid(i, j+1) {
+			right = center
+		}
+	}
+
+	if st.isUniform {
+		return 0.2*(center+top+bottom+left+right+st.sourceValue(i, j)) + st.residualValue(i, j)
+	}
+
+	// Adjacent cells are weighted by the conductivity between them and the cell, whose mean is then mixed
+	// with the current value of the cell like in the uniform case
+	kTop, kBottom, kLeft, kRight := T(st.faceConductivity(i, j, i-1, j)), T(st.faceConductivity(i, j, i+1, j)), T(st.faceConductivity(i, j, i, j-1)), T(st.faceConductivity(i, j, i, j+1))
+	return 0.2*center + 0.8*(kTop*top+kBottom*bottom+kLeft*left+kRight*right+st.sourceValue(i, j))/(kTop+kBottom+kLeft+kRight) + st.residualValue(i, j)
+}
+
+// weight computes the factor turning the difference between the new value of the inner cell in the (i, j) position and its value
+// into the residual of the linear system solved by the stencil, whose matrix is symmetric: the sum of the conductivity of its faces
+// over the weight of the adjacent cells in newValue
+func (st stencil[T]) weight(i, j int) T {
+	if st.isUniform {
+		return 5.0
+	}
+	return T((st.faceConductivity(i, j, i-1, j) + st.faceConductivity(i, j, i+1, j) + st.faceConductivity(i, j, i, j-1) + st.faceConductivity(i, j, i, j+1)) / 0.8)
+}
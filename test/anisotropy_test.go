@@ -0,0 +1,106 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"testing"
+)
+
+func TestSolveAnisotropy(t *testing.T) {
+	nDim := 16
+	isotropic := jacobi.NewProblem(0.5, nDim)
+
+	// Scaling the conductivity equally along both directions doesn't change the Laplace solution
+	scaled := isotropic
+	scaled.Anisotropy = jacobi.Anisotropy{X: 2.0, Y: 2.0}
+
+	// Per-cell conductivity along each direction matches the global factors
+	global := isotropic
+	global.Anisotropy = jacobi.Anisotropy{X: 3.0, Y: 1.0}
+	perCell := isotropic
+	perCell.ConductivityX = matrix.NewOneDimMatrix(3.0, nDim+2, 3.0, 3.0, 3.0, 3.0)
+	perCell.ConductivityY = matrix.NewOneDimMatrix(1.0, nDim+2, 1.0, 1.0, 1.0, 1.0)
+	// A factor which isn't set doesn't scale the conductivity along its direction
+	xOnly := isotropic
+	xOnly.Anisotropy = jacobi.Anisotropy{X: 3.0}
+
+	for _, nThreads := range []int{1, 4, 16} {
+		solver := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithTolerance(1.0e-10), jacobi.WithMaxIters(10000))
+
+		for _, pair := range [][2]jacobi.Problem{{isotropic, scaled}, {global, perCell}, {global, xOnly}} {
+			expected, err := solver.Solve(pair[0])
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			actual, err := solver.Solve(pair[1])
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if expected.Iterations != actual.Iterations {
+				t.Errorf("Expected %d iterations with num threads=%d, got %d", expected.Iterations, nThreads, actual.Iterations)
+			}
+			if !matrix.CompareMatrices(expected.Matrix, actual.Matrix) {
+				t.Errorf("Expected matrices to match with num threads=%d", nThreads)
+			}
+		}
+	}
+
+	invalid := isotropic
+	invalid.Anisotropy = jacobi.Anisotropy{X: 1.0, Y: -1.0}
+	if _, err := jacobi.NewSolver().Solve(invalid); err != jacobi.ErrNonPositiveConductivity {
+		t.Errorf("Expected error '%v', got '%v'", jacobi.ErrNonPositiveConductivity, err)
+	}
+}
+
+func TestSolveAnisotropyConvergence(t *testing.T) {
+	nDim, tolerance := 16, 1.0e-8
+	insulated := jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+
+	// Heat flows from the left edge to the right one, with insulated top and bottom edges
+	problem := jacobi.Problem{
+		InitialValue: 0.5,
+		NDim:         nDim,
+		Boundaries: jacobi.Boundaries{
+			Top:    matrix.ConstantBoundary(0.0),
+			Bottom: matrix.ConstantBoundary(0.0),
+			Left:   matrix.ConstantBoundary(matrix.Hot),
+			Right:  matrix.ConstantBoundary(matrix.Cold),
+		},
+		Conditions: jacobi.BoundaryConditions{Top: insulated, Bottom: insulated},
+	}
+
+	// The solution is linear along the rows regardless of the conductivity along the columns, but the higher it is,
+	// the smaller the weight of the neighbours along the rows in the stencil, so it takes more iterations to converge
+	prevIters := 0
+	for _, ratio := range []float64{1.0, 10.0, 100.0} {
+		problem.Anisotropy = jacobi.Anisotropy{X: 1.0, Y: ratio}
+		iters := 0
+
+		for _, nThreads := range []int{1, 4} {
+			res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithTolerance(tolerance), jacobi.WithMaxIters(100000)).Solve(problem)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if res.MaxDiff > tolerance {
+				t.Fatalf("Expected convergence with ratio=%.0f and num threads=%d, got max diff %g", ratio, nThreads, res.MaxDiff)
+			}
+			if res.Iterations <= prevIters {
+				t.Errorf("Expected more than %d iterations with ratio=%.0f and num threads=%d, got %d", prevIters, ratio, nThreads, res.Iterations)
+			}
+			iters = res.Iterations
+
+			for i := 1; i <= nDim; i++ {
+				for j := 1; j <= nDim; j++ {
+					expected := matrix.Hot - (matrix.Hot-matrix.Cold)*float64(j)/float64(nDim+1)
+					if actual := res.Matrix.GetCell(i, j); !utils.CompareFloats(actual, expected, 1.0e-4) {
+						t.Fatalf("Expected %.6f in cell (%d, %d) with ratio=%.0f and num threads=%d, got %.6f", expected, i, j, ratio, nThreads, actual)
+					}
+				}
+			}
+		}
+		prevIters = iters
+	}
+}
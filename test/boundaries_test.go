@@ -0,0 +1,323 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"math"
+	"testing"
+)
+
+func TestSolveBoundaries(t *testing.T) {
+	nDim := 16
+	problem := jacobi.Problem{
+		InitialValue: 0.25,
+		NDim:         nDim,
+		Boundaries: jacobi.Boundaries{
+			Top:    matrix.ConstantBoundary(0.1),
+			Bottom: matrix.ConstantBoundary(0.9),
+			Left:   matrix.ConstantBoundary(0.4),
+			Right:  matrix.ConstantBoundary(0.7),
+		},
+	}
+	matrixTypes := []matrix.MatrixType{matrix.TwoDimDividedMatrixType, matrix.TwoDimContiguousMatrixType, matrix.OneDimMatrixType}
+
+	for _, matrixType := range matrixTypes {
+		singleRes, err := jacobi.NewSolver(jacobi.WithMatrixType(matrixType)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		multiRes, err := jacobi.NewSolver(jacobi.WithMatrixType(matrixType), jacobi.WithThreads(4)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !matrix.CompareMatrices(singleRes.Matrix, multiRes.Matrix) {
+			t.Errorf("Expected multithreaded matrix to match single-threaded one for matrix type='%s'", matrixType.ToString())
+		}
+		for k := 1; k <= nDim; k++ {
+			mat := singleRes.Matrix
+			if mat.GetCell(0, k) != 0.1 || mat.GetCell(nDim+1, k) != 0.9 || mat.GetCell(k, 0) != 0.4 || mat.GetCell(k, nDim+1) != 0.7 {
+				t.Errorf("Expected boundaries to keep their values for matrix type='%s'", matrixType.ToString())
+			}
+		}
+	}
+}
+
+func TestSolveBoundaryProfiles(t *testing.T) {
+	nDim := 16
+	rightValues := make(matrix.ValuesBoundary, nDim+2)
+	for k := range rightValues {
+		rightValues[k] = float64(k%3) / 2
+	}
+	problem := jacobi.Problem{
+		InitialValue: 0.5,
+		NDim:         nDim,
+		Boundaries: jacobi.Boundaries{
+			Top:    matrix.LinearBoundary(matrix.Cold, matrix.Hot),
+			Bottom: matrix.ProfileBoundary(func(x float64) float64 { return math.Sin(math.Pi * x) }),
+			Left:   matrix.ConstantBoundary(matrix.Cold),
+			Right:  rightValues,
+		},
+	}
+
+	singleRes, err := jacobi.NewSolver().Solve(problem)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	multiRes, err := jacobi.NewSolver(jacobi.WithThreads(4)).Solve(problem)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !matrix.CompareMatrices(singleRes.Matrix, multiRes.Matrix) {
+		t.Errorf("Expected multithreaded matrix to match single-threaded one")
+	}
+	for k := 1; k <= nDim; k++ {
+		x := float64(k) / float64(nDim+1)
+		mat := multiRes.Matrix
+		if !utils.CompareFloats(mat.GetCell(0, k), x, utils.Epsilon) ||
+			!utils.CompareFloats(mat.GetCell(nDim+1, k), math.Sin(math.Pi*x), utils.Epsilon) ||
+			mat.GetCell(k, 0) != matrix.Cold || mat.GetCell(k, nDim+1) != rightValues[k] {
+			t.Errorf("Expected boundaries to follow their profiles at position %d", k)
+		}
+	}
+}
+
+func TestSolveInvalidBoundaries(t *testing.T) {
+	problem := jacobi.NewProblem(0.5, 16)
+	problem.Boundaries.Left = nil
+	if _, err := jacobi.NewSolver().Solve(problem); err != jacobi.ErrMissingBoundary {
+		t.Errorf("Expected error '%v', got '%v'", jacobi.ErrMissingBoundary, err)
+	}
+
+	problem.Boundaries.Left = make(matrix.ValuesBoundary, 16)
+	if _, err := jacobi.NewSolver().Solve(problem); err != jacobi.ErrBoundaryLength {
+		t.Errorf("Expected error '%v', got '%v'", jacobi.ErrBoundaryLength, err)
+	}
+}
+
+func TestSolveNeumannBoundaries(t *testing.T) {
+	nDim, flux := 16, 0.01
+	insulated := jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+	testCases := []struct {
+		problem jacobi.Problem
+		// Analytical solution of the row, as insulated left and right edges turn the problem into a 1D one
+		expected func(i int) float64
+	}{
+		{
+			// Hot top edge and cold bottom edge
+			jacobi.Problem{
+				InitialValue: 0.5,
+				NDim:         nDim,
+				Boundaries: jacobi.Boundaries{
+					Top:    matrix.ConstantBoundary(matrix.Hot),
+					Bottom: matrix.ConstantBoundary(matrix.Cold),
+					Left:   matrix.ConstantBoundary(0.0),
+					Right:  matrix.ConstantBoundary(0.0),
+				},
+				Conditions: jacobi.BoundaryConditions{Left: insulated, Right: insulated},
+			},
+			func(i int) float64 { return 1.0 - float64(i)/float64(nDim+1) },
+		},
+		{
+			// Heat entering through the top edge and cold bottom edge
+			jacobi.Problem{
+				InitialValue: 0.5,
+				NDim:         nDim,
+				Boundaries: jacobi.Boundaries{
+					Top:    matrix.ConstantBoundary(flux),
+					Bottom: matrix.ConstantBoundary(matrix.Cold),
+					Left:   matrix.ConstantBoundary(0.0),
+					Right:  matrix.ConstantBoundary(0.0),
+				},
+				Conditions: jacobi.BoundaryConditions{Top: insulated, Left: insulated, Right: insulated},
+			},
+			func(i int) float64 { return flux * float64(nDim+1-i) },
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, nThreads := range []int{1, 4} {
+			res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(100000), jacobi.WithTolerance(1.0e-12)).Solve(tc.problem)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			for i := 0; i <= nDim+1; i++ {
+				for j := 1; j <= nDim; j++ {
+					if actual := res.Matrix.GetCell(i, j); !utils.CompareFloats(actual, tc.expected(i), 1.0e-6) {
+						t.Fatalf("Expected %.6f in cell (%d, %d) with num threads=%d, got %.6f", tc.expected(i), i, j, nThreads, actual)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSolvePeriodicBoundaries(t *testing.T) {
+	nDim, shift := 16, 5
+	periodic := jacobi.BoundaryCondition{Kind: jacobi.Periodic}
+
+	// Shifting the top edge values along the periodic direction must shift the whole solution
+	topValues, shiftedTopValues := make(matrix.ValuesBoundary, nDim+2), make(matrix.ValuesBoundary, nDim+2)
+	for k := 1; k <= nDim; k++ {
+		topValues[k] = 0.5 + 0.5*math.Sin(2*math.Pi*float64(k)/float64(nDim))
+	}
+	for k := 1; k <= nDim; k++ {
+		shiftedTopValues[k] = topValues[(k-1+shift)%nDim+1]
+	}
+
+	newProblem := func(top matrix.Boundary) jacobi.Problem {
+		return jacobi.Problem{
+			InitialValue: 0.5,
+			NDim:         nDim,
+			Boundaries: jacobi.Boundaries{
+				Top:    top,
+				Bottom: matrix.ConstantBoundary(matrix.Cold),
+				Left:   matrix.ConstantBoundary(matrix.Cold),
+				Right:  matrix.ConstantBoundary(matrix.Cold),
+			},
+			Conditions: jacobi.BoundaryConditions{Left: periodic, Right: periodic},
+		}
+	}
+
+	var singleMat matrix.Matrix
+	for _, nThreads := range []int{1, 4, 16} {
+		solver := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(100000), jacobi.WithTolerance(1.0e-12))
+		res, err := solver.Solve(newProblem(topValues))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if singleMat == nil {
+			singleMat = res.Matrix
+		} else if !matrix.CompareMatrices(singleMat, res.Matrix) {
+			t.Errorf("Expected matrix with num threads=%d to match single-threaded one", nThreads)
+		}
+		shiftedRes, err := solver.Solve(newProblem(shiftedTopValues))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for i := 1; i <= nDim; i++ {
+			// Periodic edges are a copy of the opposite inner cells
+			if res.Matrix.GetCell(i, 0) != res.Matrix.GetCell(i, nDim) || res.Matrix.GetCell(i, nDim+1) != res.Matrix.GetCell(i, 1) {
+				t.Errorf("Expected periodic edges to wrap around in row %d with num threads=%d", i, nThreads)
+			}
+			for j := 1; j <= nDim; j++ {
+				expected, actual := res.Matrix.GetCell(i, (j-1+shift)%nDim+1), shiftedRes.Matrix.GetCell(i, j)
+				if !utils.CompareFloats(expected, actual, 1.0e-6) {
+					t.Fatalf("Expected %.6f in cell (%d, %d) with num threads=%d, got %.6f", expected, i, j, nThreads, actual)
+				}
+			}
+		}
+	}
+
+	problem := newProblem(topValues)
+	problem.Conditions.Right = jacobi.BoundaryCondition{}
+	if _, err := jacobi.NewSolver().Solve(problem); err != jacobi.ErrUnpairedPeriodicBoundary {
+		t.Errorf("Expected error '%v', got '%v'", jacobi.ErrUnpairedPeriodicBoundary, err)
+	}
+}
+
+func TestSolvePeriodicRowsBoundaries(t *testing.T) {
+	nDim, shift := 16, 5
+	periodic := jacobi.BoundaryCondition{Kind: jacobi.Periodic}
+
+	// Like TestSolvePeriodicBoundaries, but periodic along the columns, so that the first and last rows of workers share their outer cells
+	leftValues, shiftedLeftValues := make(matrix.ValuesBoundary, nDim+2), make(matrix.ValuesBoundary, nDim+2)
+	for k := 1; k <= nDim; k++ {
+		leftValues[k] = 0.5 + 0.5*math.Sin(2*math.Pi*float64(k)/float64(nDim))
+	}
+	for k := 1; k <= nDim; k++ {
+		shiftedLeftValues[k] = leftValues[(k-1+shift)%nDim+1]
+	}
+
+	newProblem := func(left matrix.Boundary) jacobi.Problem {
+		return jacobi.Problem{
+			InitialValue: 0.5,
+			NDim:         nDim,
+			Boundaries: jacobi.Boundaries{
+				Top:    matrix.ConstantBoundary(matrix.Cold),
+				Bottom: matrix.ConstantBoundary(matrix.Cold),
+				Left:   left,
+				Right:  matrix.ConstantBoundary(matrix.Cold),
+			},
+			Conditions: jacobi.BoundaryConditions{Top: periodic, Bottom: periodic},
+		}
+	}
+
+	var singleMat matrix.Matrix
+	for _, nThreads := range []int{1, 4, 16} {
+		solver := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(100000), jacobi.WithTolerance(1.0e-12))
+		res, err := solver.Solve(newProblem(leftValues))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if singleMat == nil {
+			singleMat = res.Matrix
+		} else if !matrix.CompareMatrices(singleMat, res.Matrix) {
+			t.Errorf("Expected matrix with num threads=%d to match single-threaded one", nThreads)
+		}
+		shiftedRes, err := solver.Solve(newProblem(shiftedLeftValues))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for j := 1; j <= nDim; j++ {
+			// Periodic edges are a copy of the opposite inner cells
+			if res.Matrix.GetCell(0, j) != res.Matrix.GetCell(nDim, j) || res.Matrix.GetCell(nDim+1, j) != res.Matrix.GetCell(1, j) {
+				t.Errorf("Expected periodic edges to wrap around in column %d with num threads=%d", j, nThreads)
+			}
+			for i := 1; i <= nDim; i++ {
+				expected, actual := res.Matrix.GetCell((i-1+shift)%nDim+1, j), shiftedRes.Matrix.GetCell(i, j)
+				if !utils.CompareFloats(expected, actual, 1.0e-6) {
+					t.Fatalf("Expected %.6f in cell (%d, %d) with num threads=%d, got %.6f", expected, i, j, nThreads, actual)
+				}
+			}
+		}
+	}
+}
+
+func TestSolveRobinBoundaries(t *testing.T) {
+	nDim, topValue, ambient, coefficient := 16, matrix.Hot, 0.2, 0.1
+	insulated := jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+	convective := jacobi.BoundaryCondition{Kind: jacobi.Robin, HeatTransferCoefficient: coefficient}
+
+	for _, spacing := range []float64{1.0, 0.5} {
+		problem := jacobi.Problem{
+			InitialValue: 0.5,
+			NDim:         nDim,
+			Boundaries: jacobi.Boundaries{
+				Top:    matrix.ConstantBoundary(topValue),
+				Bottom: matrix.ConstantBoundary(ambient),
+				Left:   matrix.ConstantBoundary(0.0),
+				Right:  matrix.ConstantBoundary(0.0),
+			},
+			Conditions: jacobi.BoundaryConditions{Bottom: convective, Left: insulated, Right: insulated},
+			Spacing:    spacing,
+		}
+
+		// 1D analytical solution: the temperature decreases linearly from the top edge (x=0) and the heat flowing
+		// through the space is the one exchanged with the ambient at the bottom surface, which lies halfway between
+		// the last inner row and the bottom edge
+		length := (float64(nDim) + 0.5) * spacing
+		slope := -coefficient * (topValue - ambient) / (1 + coefficient*length)
+		expected := func(i int) float64 { return topValue + slope*float64(i)*spacing }
+
+		for _, nThreads := range []int{1, 4} {
+			res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(100000), jacobi.WithTolerance(1.0e-12)).Solve(problem)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			for i := 1; i <= nDim; i++ {
+				for j := 1; j <= nDim; j++ {
+					if actual := res.Matrix.GetCell(i, j); !utils.CompareFloats(actual, expected(i), 1.0e-6) {
+						t.Fatalf("Expected %.6f in cell (%d, %d) with spacing=%.2f and num threads=%d, got %.6f", expected(i), i, j, spacing, nThreads, actual)
+					}
+				}
+			}
+		}
+	}
+}
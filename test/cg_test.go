@@ -0,0 +1,107 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"testing"
+)
+
+func TestSolveConjugateGradient(t *testing.T) {
+	nDim, tolerance := 32, 1.0e-10
+	problem := jacobi.NewProblem(0.5, nDim)
+	problem.Source = matrix.NewOneDimMatrix(0.01, nDim+2, 0.0, 0.0, 0.0, 0.0)
+	problem.Boundaries.Top = matrix.LinearBoundary(0.0, 1.0)
+	problem.Conditions.Right = jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+	problem.Boundaries.Right = matrix.ConstantBoundary(0.0)
+
+	expected, err := jacobi.NewSolver(jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance)).Solve(problem)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, matrixType := range []matrix.MatrixType{matrix.TwoDimDividedMatrixType, matrix.TwoDimContiguousMatrixType, matrix.OneDimMatrixType} {
+		for _, nThreads := range []int{1, 4, 16} {
+			nNotified := 0
+			solver := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMatrixType(matrixType), jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance),
+				jacobi.WithMethod(jacobi.ConjugateGradientMethod), jacobi.WithIterationCallback(func(nIters int, maxDiff float64) {
+					nNotified++
+				}))
+			res, err := solver.Solve(problem)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if res.MaxDiff > tolerance || nNotified != res.Iterations {
+				t.Errorf("Expected maxDiff under %g after %d notified iterations with num threads=%d and matrix type=%s, got %g after %d", tolerance, res.Iterations, nThreads, matrixType.ToString(), res.MaxDiff, nNotified)
+			}
+			// Iterations grow linearly with the size of the problem instead of quadratically
+			if res.Iterations > expected.Iterations/10 {
+				t.Errorf("Expected at most %d iterations with num threads=%d and matrix type=%s, got %d", expected.Iterations/10, nThreads, matrixType.ToString(), res.Iterations)
+			}
+
+			// Dot products are added up in a different order by each number of threads, so matrices aren't exactly the same
+			for i := 0; i < nDim+2; i++ {
+				for j := 0; j < nDim+2; j++ {
+					if actual := res.Matrix.GetCell(i, j); !utils.CompareFloats(actual, expected.Matrix.GetCell(i, j), 1.0e-6) {
+						t.Fatalf("Expected %.10f in cell (%d, %d) with num threads=%d and matrix type=%s, got %.10f", expected.Matrix.GetCell(i, j), i, j, nThreads, matrixType.ToString(), actual)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSolveConjugateGradientFields(t *testing.T) {
+	nDim := 16
+
+	// Copper traces crossing workers submatrices, with a heater and a void wall
+	traces := jacobi.NewProblem(0.5, nDim)
+	traces.Source = matrix.NewOneDimMatrix(0.01, nDim+2, 0.0, 0.0, 0.0, 0.0)
+	traces.Conductivity = matrix.NewOneDimMatrix(0.3, nDim+2, 0.3, 0.3, 0.3, 0.3)
+	for k := 2; k <= 14; k++ {
+		traces.Conductivity.SetCell(k, 8, 4.0)
+		traces.Conductivity.SetCell(5, k, 4.0)
+	}
+	traces.Mask = jacobi.NewMask(nDim)
+	for i := 8; i <= 9; i++ {
+		for j := 8; j <= 9; j++ {
+			traces.Mask[i][j] = jacobi.Cell{Kind: jacobi.FixedCell, Value: 2.0}
+		}
+	}
+	for j := 6; j <= 11; j++ {
+		traces.Mask[4][j] = jacobi.Cell{Kind: jacobi.VoidCell}
+	}
+
+	// Periodic pipe cooled by convection on its bottom edge
+	pipe := jacobi.NewProblem(0.5, nDim)
+	pipe.Anisotropy = jacobi.Anisotropy{X: 2.0, Y: 0.5}
+	pipe.Conditions = jacobi.BoundaryConditions{
+		Left:   jacobi.BoundaryCondition{Kind: jacobi.Periodic},
+		Right:  jacobi.BoundaryCondition{Kind: jacobi.Periodic},
+		Bottom: jacobi.BoundaryCondition{Kind: jacobi.Robin, HeatTransferCoefficient: 0.1},
+	}
+	pipe.Boundaries.Bottom = matrix.ConstantBoundary(0.2)
+
+	for name, problem := range map[string]jacobi.Problem{"traces": traces, "pipe": pipe} {
+		expected, err := jacobi.NewSolver(jacobi.WithMaxIters(100000), jacobi.WithTolerance(1.0e-12)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for _, nThreads := range []int{1, 4, 16} {
+			res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithTolerance(1.0e-12), jacobi.WithMethod(jacobi.ConjugateGradientMethod)).Solve(problem)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			for i := 0; i < nDim+2; i++ {
+				for j := 0; j < nDim+2; j++ {
+					if actual := res.Matrix.GetCell(i, j); !utils.CompareFloats(actual, expected.Matrix.GetCell(i, j), 1.0e-8) {
+						t.Fatalf("Expected %.10f in cell (%d, %d) of %s problem with num threads=%d, got %.10f", expected.Matrix.GetCell(i, j), i, j, name, nThreads, actual)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"testing"
+)
+
+func TestSolveLayeredConductivity(t *testing.T) {
+	nDim, topConductivity, bottomConductivity := 16, 4.0, 0.5
+	insulated := jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+
+	// Two layers (e.g. copper and FR4) whose interface matches the border between workers submatrices
+	conductivity := matrix.NewOneDimMatrix(topConductivity, nDim+2, topConductivity, bottomConductivity, topConductivity, topConductivity)
+	for i := nDim/2 + 1; i <= nDim+1; i++ {
+		for j := 0; j <= nDim+1; j++ {
+			conductivity.SetCell(i, j, bottomConductivity)
+		}
+	}
+	problem := jacobi.Problem{
+		InitialValue: 0.5,
+		NDim:         nDim,
+		Boundaries: jacobi.Boundaries{
+			Top:    matrix.ConstantBoundary(matrix.Hot),
+			Bottom: matrix.ConstantBoundary(matrix.Cold),
+			Left:   matrix.ConstantBoundary(0.0),
+			Right:  matrix.ConstantBoundary(0.0),
+		},
+		Conditions:   jacobi.BoundaryConditions{Left: insulated, Right: insulated},
+		Conductivity: conductivity,
+	}
+
+	// 1D analytical solution: the same heat flows through every pair of adjacent rows,
+	// so the temperature drop between them is inversely proportional to their conductivity
+	faceConductivity := func(i int) float64 {
+		kA, kB := conductivity.GetCell(i, 1), conductivity.GetCell(i+1, 1)
+		return 2 * kA * kB / (kA + kB)
+	}
+	resistance := 0.0
+	for i := 0; i <= nDim; i++ {
+		resistance += 1 / faceConductivity(i)
+	}
+	expected := make([]float64, nDim+2)
+	expected[0] = matrix.Hot
+	for i := 1; i <= nDim+1; i++ {
+		expected[i] = expected[i-1] - (matrix.Hot-matrix.Cold)/resistance/faceConductivity(i-1)
+	}
+
+	for _, nThreads := range []int{1, 4} {
+		res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(1000000), jacobi.WithTolerance(1.0e-14)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for i := 1; i <= nDim; i++ {
+			for j := 1; j <= nDim; j++ {
+				if actual := res.Matrix.GetCell(i, j); !utils.CompareFloats(actual, expected[i], 1.0e-6) {
+					t.Fatalf("Expected %.6f in cell (%d, %d) with num threads=%d, got %.6f", expected[i], i, j, nThreads, actual)
+				}
+			}
+		}
+	}
+}
+
+func TestSolveConductivityDecomposition(t *testing.T) {
+	nDim := 16
+	problem := jacobi.NewProblem(0.5, nDim)
+	problem.Source = matrix.NewOneDimMatrix(0.01, nDim+2, 0.0, 0.0, 0.0, 0.0)
+
+	// Copper traces and air gaps on an FR4 board, crossing workers submatrices
+	problem.Conductivity = matrix.NewOneDimMatrix(0.3, nDim+2, 0.3, 0.3, 0.3, 0.3)
+	for k := 2; k <= 14; k++ {
+		problem.Conductivity.SetCell(k, 8, 4.0)
+		problem.Conductivity.SetCell(5, k, 4.0)
+		problem.Conductivity.SetCell(k, 12, 0.05)
+	}
+
+	var singleMat matrix.Matrix
+	for _, nThreads := range []int{1, 4, 16} {
+		res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(10000), jacobi.WithTolerance(1.0e-10)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if singleMat == nil {
+			singleMat = res.Matrix
+		} else if !matrix.CompareMatrices(singleMat, res.Matrix) {
+			t.Errorf("Expected matrix with num threads=%d to match single-threaded one", nThreads)
+		}
+	}
+
+	problem.Conductivity.SetCell(3, 3, 0.0)
+	if _, err := jacobi.NewSolver().Solve(problem); err != jacobi.ErrNonPositiveConductivity {
+		t.Errorf("Expected error '%v', got '%v'", jacobi.ErrNonPositiveConductivity, err)
+	}
+}
@@ -0,0 +1,64 @@
+package test
+
+import (
+	"context"
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSolveContextCancel(t *testing.T) {
+	cancelAt, maxIters := 5, 1000
+	nGoroutines := runtime.NumGoroutine()
+
+	for _, nThreads := range []int{1, 4} {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		res, err := jacobi.NewSolver(
+			jacobi.WithThreads(nThreads),
+			jacobi.WithMaxIters(maxIters),
+			jacobi.WithTolerance(1.0e-12),
+			jacobi.WithIterationCallback(func(nIters int, maxDiff float64) {
+				if nIters == cancelAt {
+					cancel()
+				}
+			}),
+		).SolveContext(ctx, jacobi.NewProblem(0.5, 16))
+		cancel()
+
+		if err != context.Canceled {
+			t.Errorf("Expected error '%v' with num threads=%d, got '%v'", context.Canceled, nThreads, err)
+		}
+		// Workers may only notice the cancellation when they reduce the next maxDiff
+		if res.Iterations < cancelAt || res.Iterations > cancelAt+1 {
+			t.Errorf("Expected simulation with num threads=%d to stop right after iteration %d, stopped at iteration %d", nThreads, cancelAt, res.Iterations)
+		}
+		if res.Matrix == nil || res.Matrix.GetNDim() != 18 {
+			t.Errorf("Expected partial matrix with num threads=%d", nThreads)
+		}
+	}
+
+	// Give some time to finished routines to be accounted
+	time.Sleep(10 * time.Millisecond)
+	if actual := runtime.NumGoroutine(); actual > nGoroutines {
+		t.Errorf("Expected %d routines after cancelling the simulations, got %d", nGoroutines, actual)
+	}
+}
+
+func TestRunJacobiContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	for _, nThreads := range []int{1, 4} {
+		_, nIters, _, err := jacobi.RunJacobiContext(ctx, 0.5, 16, 1000, 1.0e-4, nThreads, matrix.OneDimMatrixType)
+		if err != context.DeadlineExceeded {
+			t.Errorf("Expected error '%v' with num threads=%d, got '%v'", context.DeadlineExceeded, nThreads, err)
+		}
+		if nIters > 1 {
+			t.Errorf("Expected simulation with num threads=%d to stop at the first iteration boundary, stopped at iteration %d", nThreads, nIters)
+		}
+	}
+}
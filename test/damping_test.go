@@ -0,0 +1,65 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"testing"
+)
+
+func TestRunJacobiUndamped(t *testing.T) {
+	initialValue, nDim, maxIters, tolerance := 0.5, 16, 1000, 1.0e-4
+
+	// No damping reproduces the jacobi method
+	for _, nThreads := range []int{1, 4, 16} {
+		for _, matrixType := range []matrix.MatrixType{matrix.TwoDimDividedMatrixType, matrix.TwoDimContiguousMatrixType, matrix.OneDimMatrixType} {
+			actualMat, _, _ := jacobi.RunJacobi(initialValue, nDim, maxIters, tolerance, nThreads, matrixType, jacobi.WithDampingFactor(1.0))
+
+			if !matrix.CompareMatrices(actualMat, expectedJacobiMat) {
+				t.Errorf("Expected golden matrix with num threads=%d and matrix type=%s", nThreads, matrixType.ToString())
+			}
+		}
+	}
+}
+
+func TestSolveDamped(t *testing.T) {
+	nDim, tolerance := 16, 1.0e-10
+	problem := jacobi.NewProblem(0.5, nDim)
+	problem.Source = matrix.NewOneDimMatrix(0.01, nDim+2, 0.0, 0.0, 0.0, 0.0)
+	problem.Conditions.Right = jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+	problem.Boundaries.Right = matrix.ConstantBoundary(0.0)
+
+	for _, nThreads := range []int{1, 4, 16} {
+		undamped, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		// Damping slows the convergence down, but it reaches the same field
+		damped, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance), jacobi.WithDampingFactor(0.5)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if damped.Iterations <= undamped.Iterations {
+			t.Errorf("Expected more than %d iterations with w=0.5 and num threads=%d, got %d", undamped.Iterations, nThreads, damped.Iterations)
+		}
+		for i := 0; i < nDim+2; i++ {
+			for j := 0; j < nDim+2; j++ {
+				if actual := damped.Matrix.GetCell(i, j); !utils.CompareFloats(actual, undamped.Matrix.GetCell(i, j), 1.0e-7) {
+					t.Fatalf("Expected %.10f in cell (%d, %d) with w=0.5 and num threads=%d, got %.10f", undamped.Matrix.GetCell(i, j), i, j, nThreads, actual)
+				}
+			}
+		}
+	}
+
+	// Factors up to the limit still converge
+	res, err := jacobi.NewSolver(jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance), jacobi.WithDampingFactor(1.2)).Solve(problem)
+	if err != nil || res.MaxDiff > tolerance {
+		t.Errorf("Expected convergence with w=1.2, got max diff %g and error '%v'", res.MaxDiff, err)
+	}
+	for _, w := range []float64{0.0, -0.5, 1.25, 3.0} {
+		if _, err := jacobi.NewSolver(jacobi.WithDampingFactor(w)).Solve(problem); err != jacobi.ErrDampingFactorOutOfRange {
+			t.Errorf("Expected error '%v' with w=%.2f, got '%v'", jacobi.ErrDampingFactorOutOfRange, w, err)
+		}
+	}
+}
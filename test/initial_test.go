@@ -0,0 +1,97 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"math"
+	"testing"
+)
+
+func TestSolveInitialCondition(t *testing.T) {
+	nDim := 16
+	problem := jacobi.NewProblem(0.5, nDim)
+	problem.InitialCondition = func(i, j int) float64 {
+		return math.Sin(math.Pi*float64(i)/float64(nDim+1)) * math.Sin(math.Pi*float64(j)/float64(nDim+1))
+	}
+
+	// A single iteration leaves the initial condition visible in the result
+	var singleMat matrix.Matrix
+	for _, nThreads := range []int{1, 4, 16} {
+		res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(1)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if singleMat == nil {
+			singleMat = res.Matrix
+		} else if !matrix.CompareMatrices(singleMat, res.Matrix) {
+			t.Errorf("Expected matrix with num threads=%d to match single-threaded one", nThreads)
+		}
+	}
+
+	uniform, err := jacobi.NewSolver(jacobi.WithMaxIters(1)).Solve(jacobi.NewProblem(0.5, nDim))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if matrix.CompareMatrices(uniform.Matrix, singleMat) {
+		t.Errorf("Expected initial condition to be used instead of the initial value")
+	}
+}
+
+func TestSolveWarmStart(t *testing.T) {
+	nDim, tolerance := 16, 1.0e-8
+	problem := jacobi.NewProblem(0.5, nDim)
+
+	for _, nThreads := range []int{1, 4} {
+		solver := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance))
+
+		cold, err := solver.Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		// Seeding the solution converges straight away
+		seeded := problem
+		seeded.InitialMatrix = cold.Matrix
+		res, err := solver.Solve(seeded)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if res.Iterations != 1 {
+			t.Errorf("Expected 1 iteration when seeding the solution with num threads=%d, got %d", nThreads, res.Iterations)
+		}
+
+		// Slightly changing a boundary takes fewer iterations when warm-starting from the previous solution,
+		// whose edges cells are ignored
+		changed := problem
+		changed.Boundaries.Bottom = matrix.ConstantBoundary(0.1)
+		changedCold, err := solver.Solve(changed)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		changed.InitialMatrix = cold.Matrix
+		changedWarm, err := solver.Solve(changed)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if changedWarm.Iterations >= changedCold.Iterations {
+			t.Errorf("Expected fewer than %d iterations when warm-starting with num threads=%d, got %d", changedCold.Iterations, nThreads, changedWarm.Iterations)
+		}
+		if changedWarm.Matrix.GetCell(nDim+1, 1) != 0.1 {
+			t.Errorf("Expected bottom edge to take the new boundary with num threads=%d, got %.4f", nThreads, changedWarm.Matrix.GetCell(nDim+1, 1))
+		}
+		for i := 1; i <= nDim; i++ {
+			for j := 1; j <= nDim; j++ {
+				if diff := math.Abs(changedWarm.Matrix.GetCell(i, j) - changedCold.Matrix.GetCell(i, j)); diff > 1.0e-5 {
+					t.Fatalf("Expected warm and cold starts to reach the same solution in cell (%d, %d) with num threads=%d, got diff %g", i, j, nThreads, diff)
+				}
+			}
+		}
+	}
+
+	problem.InitialMatrix = matrix.NewOneDimMatrix(0.5, nDim, 1.0, 0.0, 1.0, 1.0)
+	if _, err := jacobi.NewSolver().Solve(problem); err != jacobi.ErrInitialMatrixSize {
+		t.Errorf("Expected error '%v', got '%v'", jacobi.ErrInitialMatrixSize, err)
+	}
+}
@@ -0,0 +1,129 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"testing"
+)
+
+func TestSolveMask(t *testing.T) {
+	nDim, initialValue := 16, 0.5
+	problem := jacobi.NewProblem(initialValue, nDim)
+	problem.Mask = jacobi.NewMask(nDim)
+
+	// Heater in the center of the space, which straddles the submatrices of 4 workers
+	for i := 8; i <= 9; i++ {
+		for j := 8; j <= 9; j++ {
+			problem.Mask[i][j] = jacobi.Cell{Kind: jacobi.FixedCell, Value: 2.0}
+		}
+	}
+	// Void wall across the border of 2 workers
+	for j := 6; j <= 11; j++ {
+		problem.Mask[4][j] = jacobi.Cell{Kind: jacobi.VoidCell}
+	}
+
+	var singleMat matrix.Matrix
+	for _, nThreads := range []int{1, 4, 16} {
+		res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(10000), jacobi.WithTolerance(1.0e-10)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if singleMat == nil {
+			singleMat = res.Matrix
+		} else if !matrix.CompareMatrices(singleMat, res.Matrix) {
+			t.Errorf("Expected matrix with num threads=%d to match single-threaded one", nThreads)
+		}
+		for i := 1; i <= nDim; i++ {
+			for j := 1; j <= nDim; j++ {
+				cell, actual := problem.Mask[i][j], res.Matrix.GetCell(i, j)
+				if (cell.Kind == jacobi.FixedCell && actual != cell.Value) || (cell.Kind == jacobi.VoidCell && actual != initialValue) {
+					t.Errorf("Expected cell (%d, %d) to keep its value with num threads=%d, got %.6f", i, j, nThreads, actual)
+				}
+			}
+		}
+	}
+}
+
+func TestSolveMaskVoidWalls(t *testing.T) {
+	nDim := 16
+	problem := jacobi.Problem{
+		InitialValue: 0.5,
+		NDim:         nDim,
+		Boundaries:   jacobi.DefaultBoundaries,
+		Mask:         jacobi.NewMask(nDim),
+	}
+
+	// Void columns insulating the rest of columns from the left and right edges and from each other,
+	// which turns the problem into a 1D one
+	voidColumns := map[int]bool{1: true, 9: true, nDim: true}
+	for i := 1; i <= nDim; i++ {
+		for j := range voidColumns {
+			problem.Mask[i][j] = jacobi.Cell{Kind: jacobi.VoidCell}
+		}
+	}
+
+	for _, nThreads := range []int{1, 4, 16} {
+		res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(100000), jacobi.WithTolerance(1.0e-12)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for i := 1; i <= nDim; i++ {
+			expected := 1.0 - float64(i)/float64(nDim+1)
+			for j := 1; j <= nDim; j++ {
+				if actual := res.Matrix.GetCell(i, j); !voidColumns[j] && !utils.CompareFloats(actual, expected, 1.0e-6) {
+					t.Fatalf("Expected %.6f in cell (%d, %d) with num threads=%d, got %.6f", expected, i, j, nThreads, actual)
+				}
+			}
+		}
+	}
+
+	problem.Mask = jacobi.NewMask(nDim - 1)
+	if _, err := jacobi.NewSolver().Solve(problem); err != jacobi.ErrMaskSize {
+		t.Errorf("Expected error '%v', got '%v'", jacobi.ErrMaskSize, err)
+	}
+}
+
+func TestSolveMaskPeriodic(t *testing.T) {
+	nDim, voidValue := 16, 100.0
+	periodic := jacobi.BoundaryCondition{Kind: jacobi.Periodic}
+	problem := jacobi.Problem{
+		InitialValue: 0.5,
+		NDim:         nDim,
+		Boundaries:   jacobi.DefaultBoundaries,
+		Conditions:   jacobi.BoundaryConditions{Left: periodic, Right: periodic},
+		Mask:         jacobi.NewMask(nDim),
+	}
+
+	// Void column next to the right edge, which insulates the first and last columns from each other across the periodic edges
+	for i := 1; i <= nDim; i++ {
+		problem.Mask[i][nDim] = jacobi.Cell{Kind: jacobi.VoidCell}
+	}
+	problem.InitialCondition = func(i, j int) float64 {
+		if j == nDim {
+			return voidValue
+		}
+		return 0.5
+	}
+
+	for _, nThreads := range []int{1, 4} {
+		res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(100000), jacobi.WithTolerance(1.0e-12)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for i := 1; i <= nDim; i++ {
+			expected := 1.0 - float64(i)/float64(nDim+1)
+			if actual := res.Matrix.GetCell(i, nDim); actual != voidValue {
+				t.Errorf("Expected void cell (%d, %d) to keep its value with num threads=%d, got %.6f", i, nDim, nThreads, actual)
+			}
+			for j := 1; j < nDim; j++ {
+				if actual := res.Matrix.GetCell(i, j); !utils.CompareFloats(actual, expected, 1.0e-6) {
+					t.Fatalf("Expected %.6f in cell (%d, %d) with num threads=%d, got %.6f", expected, i, j, nThreads, actual)
+				}
+			}
+		}
+	}
+}
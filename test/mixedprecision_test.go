@@ -0,0 +1,69 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"testing"
+)
+
+func TestSolveMixedPrecision(t *testing.T) {
+	nDim, tolerance := 16, 1.0e-10
+	problem := jacobi.NewProblem(0.5, nDim)
+	problem.Source = matrix.NewOneDimMatrix(0.01, nDim+2, 0.0, 0.0, 0.0, 0.0)
+	problem.Conditions.Right = jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+	problem.Boundaries.Right = matrix.ConstantBoundary(0.0)
+	problem.Mask = jacobi.NewMask(nDim)
+	problem.Mask[5][5] = jacobi.Cell{Kind: jacobi.FixedCell, Value: 2.0}
+	problem.Mask[10][12] = jacobi.Cell{Kind: jacobi.VoidCell}
+
+	expected, err := jacobi.NewSolver(jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance)).Solve(problem)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, nThreads := range []int{1, 4, 16} {
+		solver := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance), jacobi.WithMethod(jacobi.MixedPrecisionMethod))
+		res, err := solver.Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		// The tolerance is beyond single precision, so the solution must have been refined several times
+		if res.MaxDiff > tolerance {
+			t.Errorf("Expected max diff under %g with num threads=%d, got %g", tolerance, nThreads, res.MaxDiff)
+		}
+		if res.HighPrecisionSweeps < 2 || res.LowPrecisionSweeps <= res.HighPrecisionSweeps {
+			t.Errorf("Expected bulk of low precision sweeps and several high precision ones with num threads=%d, got %d and %d", nThreads, res.LowPrecisionSweeps, res.HighPrecisionSweeps)
+		}
+		if res.LowPrecisionSweeps+res.HighPrecisionSweeps != res.Iterations {
+			t.Errorf("Expected %d low and high precision sweeps with num threads=%d, got %d", res.Iterations, nThreads, res.LowPrecisionSweeps+res.HighPrecisionSweeps)
+		}
+
+		for i := 0; i < nDim+2; i++ {
+			for j := 0; j < nDim+2; j++ {
+				if actual := res.Matrix.GetCell(i, j); !utils.CompareFloats(actual, expected.Matrix.GetCell(i, j), 1.0e-7) {
+					t.Fatalf("Expected %.10f in cell (%d, %d) with num threads=%d, got %.10f", expected.Matrix.GetCell(i, j), i, j, nThreads, actual)
+				}
+			}
+		}
+	}
+}
+
+func TestRunJacobiMixedPrecision(t *testing.T) {
+	initialValue, nDim, maxIters, tolerance := 0.5, 16, 1000, 1.0e-4
+
+	expectedMat, _, _ := jacobi.RunJacobi(initialValue, nDim, maxIters, 1.0e-8, 4, matrix.OneDimMatrixType)
+	resMat, nIters, maxDiff := jacobi.RunJacobi(initialValue, nDim, maxIters, tolerance, 4, matrix.OneDimMatrixType, jacobi.WithMethod(jacobi.MixedPrecisionMethod))
+
+	if nIters > maxIters || maxDiff > tolerance {
+		t.Errorf("Expected convergence within %d iterations, got %d iterations and max diff %g", maxIters, nIters, maxDiff)
+	}
+	for i := 1; i <= nDim; i++ {
+		for j := 1; j <= nDim; j++ {
+			if !utils.CompareFloats(resMat.GetCell(i, j), expectedMat.GetCell(i, j), 1.0e-2) {
+				t.Fatalf("Expected %.4f in cell (%d, %d), got %.4f", expectedMat.GetCell(i, j), i, j, resMat.GetCell(i, j))
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"testing"
+)
+
+func TestSolveMultigrid(t *testing.T) {
+	tolerance := 1.0e-10
+	testCases := []struct {
+		nDim, levels int
+	}{
+		{16, 0},
+		{16, 3},
+		{64, 0},
+	}
+
+	for _, testCase := range testCases {
+		nDim, levels := testCase.nDim, testCase.levels
+		problem := jacobi.NewProblem(0.5, nDim)
+		problem.Source = matrix.NewOneDimMatrix(0.01, nDim+2, 0.0, 0.0, 0.0, 0.0)
+		problem.Conditions.Right = jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+		problem.Boundaries.Right = matrix.ConstantBoundary(0.0)
+		problem.Conditions.Bottom = jacobi.BoundaryCondition{Kind: jacobi.Robin, HeatTransferCoefficient: 0.5}
+
+		expected, err := jacobi.NewSolver(jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance), jacobi.WithMethod(jacobi.SORMethod)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for _, cycle := range []jacobi.Cycle{jacobi.VCycle, jacobi.WCycle} {
+			for _, matrixType := range []matrix.MatrixType{matrix.TwoDimDividedMatrixType, matrix.TwoDimContiguousMatrixType, matrix.OneDimMatrixType} {
+				solver := jacobi.NewSolver(jacobi.WithTolerance(tolerance), jacobi.WithMatrixType(matrixType), jacobi.WithMethod(jacobi.MultigridMethod), jacobi.WithCycle(cycle), jacobi.WithLevels(levels))
+				res, err := solver.Solve(problem)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				// The number of cycles barely depends on the size of the problem
+				if res.MaxDiff > tolerance || res.Iterations > 20 {
+					t.Errorf("Expected convergence within 20 iterations with num dims=%d, %s, levels=%d and matrix type=%s, got %d iterations and max diff %g", nDim, cycle.ToString(), levels, matrixType.ToString(), res.Iterations, res.MaxDiff)
+				}
+				for i := 0; i < nDim+2; i++ {
+					for j := 0; j < nDim+2; j++ {
+						if actual := res.Matrix.GetCell(i, j); !utils.CompareFloats(actual, expected.Matrix.GetCell(i, j), 1.0e-6) {
+							t.Fatalf("Expected %.10f in cell (%d, %d) with num dims=%d, %s, levels=%d and matrix type=%s, got %.10f", expected.Matrix.GetCell(i, j), i, j, nDim, cycle.ToString(), levels, matrixType.ToString(), actual)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRunJacobiMultigrid(t *testing.T) {
+	initialValue, nDim, maxIters, tolerance := 0.5, 16, 1000, 1.0e-4
+
+	// The golden matrix approximates the solution up to its tolerance
+	resMat, nIters, maxDiff := jacobi.RunJacobi(initialValue, nDim, maxIters, tolerance, 1, matrix.OneDimMatrixType, jacobi.WithMethod(jacobi.MultigridMethod))
+	if nIters > 10 || maxDiff > tolerance {
+		t.Errorf("Expected convergence within 10 iterations, got %d iterations and max diff %g", nIters, maxDiff)
+	}
+	for i := 0; i < nDim+2; i++ {
+		for j := 0; j < nDim+2; j++ {
+			if !utils.CompareFloats(resMat.GetCell(i, j), expectedJacobiMat.GetCell(i, j), 1.0e-2) {
+				t.Fatalf("Expected %.4f in cell (%d, %d), got %.4f", expectedJacobiMat.GetCell(i, j), i, j, resMat.GetCell(i, j))
+			}
+		}
+	}
+}
+
+func TestSolveInvalidMultigrid(t *testing.T) {
+	masked := jacobi.NewProblem(0.5, 16)
+	masked.Mask = jacobi.NewMask(16)
+
+	testCases := []struct {
+		problem     jacobi.Problem
+		opts        []jacobi.Option
+		expectedErr error
+	}{
+		{jacobi.NewProblem(0.5, 16), []jacobi.Option{jacobi.WithLevels(-1)}, jacobi.ErrNegativeLevels},
+		{jacobi.NewProblem(0.5, 16), []jacobi.Option{jacobi.WithLevels(5)}, jacobi.ErrTooManyLevels},
+		{jacobi.NewRectangularProblem(0.5, 16, 12), []jacobi.Option{jacobi.WithLevels(4)}, jacobi.ErrTooManyLevels},
+		{jacobi.NewProblem(0.5, 16), []jacobi.Option{jacobi.WithThreads(4)}, jacobi.ErrSequentialMethod},
+		{masked, nil, jacobi.ErrUnsupportedMultigridProblem},
+	}
+
+	for _, testCase := range testCases {
+		opts := append([]jacobi.Option{jacobi.WithMethod(jacobi.MultigridMethod)}, testCase.opts...)
+		if _, err := jacobi.NewSolver(opts...).Solve(testCase.problem); err != testCase.expectedErr {
+			t.Errorf("Expected error '%v', got '%v'", testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestRestrictProlongate(t *testing.T) {
+	// Fine cells are one unit apart, with the first inner one at 1, and each coarse cell lies at the center of the 2x2 block of fine cells it covers
+	// Linear fields are kept by both operators
+	field := func(x, y float64) float64 {
+		return 2*x - y
+	}
+	zero := matrix.ConstantBoundary(0.0)
+	newMatrix := func(rows, cols int, matrixType matrix.MatrixType) matrix.Matrix {
+		if matrixType == matrix.OneDimMatrixType {
+			return matrix.NewRectangularOneDimMatrix(0.0, rows, cols, zero, zero, zero, zero)
+		}
+		return matrix.NewRectangularTwoDimMatrix(0.0, rows, cols, zero, zero, zero, zero, matrixType)
+	}
+
+	for _, matrixType := range []matrix.MatrixType{matrix.TwoDimDividedMatrixType, matrix.TwoDimContiguousMatrixType, matrix.OneDimMatrixType} {
+		fine, coarse := newMatrix(8+2, 12+2, matrixType), newMatrix(4+2, 6+2, matrixType)
+		for i := 1; i <= 8; i++ {
+			for j := 1; j <= 12; j++ {
+				fine.SetCell(i, j, field(float64(i), float64(j)))
+			}
+		}
+
+		matrix.Restrict(fine, coarse)
+		for i := 1; i <= 4; i++ {
+			for j := 1; j <= 6; j++ {
+				if expected := field(2*float64(i)-0.5, 2*float64(j)-0.5); !utils.CompareFloats(coarse.GetCell(i, j), expected, utils.Epsilon) {
+					t.Fatalf("Expected %.4f in restricted cell (%d, %d) with matrix type=%s, got %.4f", expected, i, j, matrixType.ToString(), coarse.GetCell(i, j))
+				}
+			}
+		}
+
+		// Edges of the coarse matrix take part in the interpolation
+		for i := 0; i <= 5; i++ {
+			for j := 0; j <= 7; j++ {
+				coarse.SetCell(i, j, field(2*float64(i)-0.5, 2*float64(j)-0.5))
+			}
+		}
+		matrix.Prolongate(coarse, fine)
+		for i := 1; i <= 8; i++ {
+			for j := 1; j <= 12; j++ {
+				if expected := field(float64(i), float64(j)); !utils.CompareFloats(fine.GetCell(i, j), expected, utils.Epsilon) {
+					t.Fatalf("Expected %.4f in prolongated cell (%d, %d) with matrix type=%s, got %.4f", expected, i, j, matrixType.ToString(), fine.GetCell(i, j))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"testing"
+)
+
+func TestSolveNestedIteration(t *testing.T) {
+	nDim, tolerance := 32, 1.0e-10
+	problem := jacobi.NewProblem(0.5, nDim)
+	problem.Source = matrix.NewOneDimMatrix(0.01, nDim+2, 0.0, 0.0, 0.0, 0.0)
+	problem.Boundaries.Top = matrix.LinearBoundary(0.0, 1.0)
+	problem.Conditions.Right = jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+	problem.Boundaries.Right = matrix.ConstantBoundary(0.0)
+
+	expected, err := jacobi.NewSolver(jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance)).Solve(problem)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, matrixType := range []matrix.MatrixType{matrix.TwoDimDividedMatrixType, matrix.TwoDimContiguousMatrixType, matrix.OneDimMatrixType} {
+		var singleMat matrix.Matrix
+		for _, nThreads := range []int{1, 4} {
+			nNotified := 0
+			solver := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMatrixType(matrixType), jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance),
+				jacobi.WithMethod(jacobi.NestedIterationMethod), jacobi.WithIterationCallback(func(nIters int, maxDiff float64) {
+					nNotified++
+				}))
+			res, err := solver.Solve(problem)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			// Levels go from 2x2 to 32x32, and only the iterations of the finest one are notified
+			if len(res.LevelIterations) != 5 || res.LevelIterations[4] != res.Iterations {
+				t.Errorf("Expected 5 levels ending with %d iterations with num threads=%d and matrix type=%s, got %v", res.Iterations, nThreads, matrixType.ToString(), res.LevelIterations)
+			}
+			if nNotified != res.Iterations {
+				t.Errorf("Expected %d notified iterations with num threads=%d and matrix type=%s, got %d", res.Iterations, nThreads, matrixType.ToString(), nNotified)
+			}
+			if res.Iterations >= expected.Iterations {
+				t.Errorf("Expected fewer than %d iterations with num threads=%d and matrix type=%s, got %d", expected.Iterations, nThreads, matrixType.ToString(), res.Iterations)
+			}
+
+			if singleMat == nil {
+				singleMat = res.Matrix
+			} else if !matrix.CompareMatrices(singleMat, res.Matrix) {
+				t.Errorf("Expected matrix with num threads=%d and matrix type=%s to match single-threaded one", nThreads, matrixType.ToString())
+			}
+			for i := 0; i < nDim+2; i++ {
+				for j := 0; j < nDim+2; j++ {
+					if actual := res.Matrix.GetCell(i, j); !utils.CompareFloats(actual, expected.Matrix.GetCell(i, j), 1.0e-6) {
+						t.Fatalf("Expected %.10f in cell (%d, %d) with num threads=%d and matrix type=%s, got %.10f", expected.Matrix.GetCell(i, j), i, j, nThreads, matrixType.ToString(), actual)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSolveNestedIterationMask(t *testing.T) {
+	nDim, initialValue, tolerance := 16, 0.5, 1.0e-10
+	insulated := jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+	problem := jacobi.NewProblem(initialValue, nDim)
+	problem.Conditions = jacobi.BoundaryConditions{Left: insulated, Right: insulated}
+	problem.Boundaries.Left, problem.Boundaries.Right = matrix.ConstantBoundary(0.0), matrix.ConstantBoundary(0.0)
+	problem.Mask = jacobi.NewMask(nDim)
+
+	// Heater and void wall, which the coarse levels don't have
+	for i := 8; i <= 9; i++ {
+		for j := 8; j <= 9; j++ {
+			problem.Mask[i][j] = jacobi.Cell{Kind: jacobi.FixedCell, Value: 2.0}
+		}
+	}
+	for j := 3; j <= 13; j++ {
+		problem.Mask[4][j] = jacobi.Cell{Kind: jacobi.VoidCell}
+	}
+
+	expected, err := jacobi.NewSolver(jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance)).Solve(problem)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, nThreads := range []int{1, 4} {
+		res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance),
+			jacobi.WithMethod(jacobi.NestedIterationMethod)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for i := 0; i < nDim+2; i++ {
+			for j := 0; j < nDim+2; j++ {
+				cell, actual := problem.Mask[i][j], res.Matrix.GetCell(i, j)
+				if (cell.Kind == jacobi.FixedCell && actual != cell.Value) || (cell.Kind == jacobi.VoidCell && actual != initialValue) {
+					t.Errorf("Expected cell (%d, %d) to keep its value with num threads=%d, got %.6f", i, j, nThreads, actual)
+				}
+				if !utils.CompareFloats(actual, expected.Matrix.GetCell(i, j), 1.0e-6) {
+					t.Fatalf("Expected %.10f in cell (%d, %d) with num threads=%d, got %.10f", expected.Matrix.GetCell(i, j), i, j, nThreads, actual)
+				}
+			}
+		}
+	}
+}
+
+func TestSolveNestedIterationLevels(t *testing.T) {
+	problem := jacobi.NewRectangularProblem(0.5, 16, 24)
+
+	res, err := jacobi.NewSolver(jacobi.WithMethod(jacobi.NestedIterationMethod), jacobi.WithLevels(2)).Solve(problem)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(res.LevelIterations) != 2 {
+		t.Errorf("Expected 2 levels, got %v", res.LevelIterations)
+	}
+
+	// 24 columns can only be halved three times
+	if _, err := jacobi.NewSolver(jacobi.WithMethod(jacobi.NestedIterationMethod), jacobi.WithLevels(5)).Solve(problem); err != jacobi.ErrTooManyLevels {
+		t.Errorf("Expected error '%v', got '%v'", jacobi.ErrTooManyLevels, err)
+	}
+}
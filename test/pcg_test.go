@@ -0,0 +1,108 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"testing"
+)
+
+// sweepsPreconditioner is a preconditioner made of a few jacobi sweeps of the correction problem, which is solved like any other problem
+type sweepsPreconditioner struct {
+	nSweeps int
+}
+
+func (preconditioner sweepsPreconditioner) Setup(problem jacobi.Problem, residual matrix.Matrix) (func(correction matrix.Matrix), error) {
+	solver := jacobi.NewSolver(jacobi.WithMaxIters(preconditioner.nSweeps), jacobi.WithTolerance(1.0e-300))
+
+	return func(correction matrix.Matrix) {
+		res, _ := solver.Solve(problem)
+		for i := 1; i < correction.GetRows()-1; i++ {
+			for j := 1; j < correction.GetCols()-1; j++ {
+				correction.SetCell(i, j, res.Matrix.GetCell(i, j))
+			}
+		}
+	}, nil
+}
+
+func TestSolvePreconditionedConjugateGradient(t *testing.T) {
+	nDim, tolerance := 32, 1.0e-10
+	problem := jacobi.NewProblem(0.5, nDim)
+	problem.Source = matrix.NewOneDimMatrix(0.01, nDim+2, 0.0, 0.0, 0.0, 0.0)
+	problem.Boundaries.Top = matrix.LinearBoundary(0.0, 1.0)
+	problem.Conditions.Right = jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+	problem.Boundaries.Right = matrix.ConstantBoundary(0.0)
+
+	expected, err := jacobi.NewSolver(jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance)).Solve(problem)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	unpreconditioned, err := jacobi.NewSolver(jacobi.WithTolerance(tolerance), jacobi.WithMethod(jacobi.ConjugateGradientMethod)).Solve(problem)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name           string
+		preconditioner jacobi.Preconditioner
+		// Maximum number of iterations relative to the unpreconditioned method
+		maxItersRatio float64
+	}{
+		// The weight of every cell is the same, so the diagonal preconditioner only scales the residual
+		{"diagonal", jacobi.DiagonalPreconditioner{}, 1.0},
+		{"symmetric Gauss-Seidel", jacobi.SSORPreconditioner{}, 0.6},
+		{"SSOR", jacobi.SSORPreconditioner{RelaxationFactor: 1.5}, 0.5},
+		{"V-cycle", jacobi.MultigridPreconditioner{}, 0.1},
+		{"W-cycle", jacobi.MultigridPreconditioner{Cycle: jacobi.WCycle, Levels: 3}, 0.1},
+		{"jacobi sweeps", sweepsPreconditioner{nSweeps: 4}, 0.5},
+	}
+
+	for _, tc := range testCases {
+		for _, matrixType := range []matrix.MatrixType{matrix.TwoDimDividedMatrixType, matrix.OneDimMatrixType} {
+			solver := jacobi.NewSolver(jacobi.WithMatrixType(matrixType), jacobi.WithTolerance(tolerance), jacobi.WithMethod(jacobi.ConjugateGradientMethod),
+				jacobi.WithPreconditioner(tc.preconditioner))
+			res, err := solver.Solve(problem)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if res.MaxDiff > tolerance {
+				t.Errorf("Expected maxDiff under %g with %s preconditioner and matrix type=%s, got %g", tolerance, tc.name, matrixType.ToString(), res.MaxDiff)
+			}
+			if maxIters := int(tc.maxItersRatio * float64(unpreconditioned.Iterations)); res.Iterations > maxIters {
+				t.Errorf("Expected at most %d iterations with %s preconditioner and matrix type=%s, got %d", maxIters, tc.name, matrixType.ToString(), res.Iterations)
+			}
+			for i := 0; i < nDim+2; i++ {
+				for j := 0; j < nDim+2; j++ {
+					if actual := res.Matrix.GetCell(i, j); !utils.CompareFloats(actual, expected.Matrix.GetCell(i, j), 1.0e-6) {
+						t.Fatalf("Expected %.10f in cell (%d, %d) with %s preconditioner and matrix type=%s, got %.10f", expected.Matrix.GetCell(i, j), i, j, tc.name, matrixType.ToString(), actual)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSolveInvalidPreconditioner(t *testing.T) {
+	problem := jacobi.NewProblem(0.5, 16)
+	maskedProblem := problem
+	maskedProblem.Mask = jacobi.NewMask(16)
+
+	testCases := []struct {
+		problem  jacobi.Problem
+		opts     []jacobi.Option
+		expected error
+	}{
+		{problem, []jacobi.Option{jacobi.WithThreads(4), jacobi.WithPreconditioner(jacobi.DiagonalPreconditioner{})}, jacobi.ErrSequentialMethod},
+		{problem, []jacobi.Option{jacobi.WithPreconditioner(jacobi.SSORPreconditioner{RelaxationFactor: 2.5})}, jacobi.ErrRelaxationFactorOutOfRange},
+		{problem, []jacobi.Option{jacobi.WithPreconditioner(jacobi.MultigridPreconditioner{Levels: 5})}, jacobi.ErrTooManyLevels},
+		{maskedProblem, []jacobi.Option{jacobi.WithPreconditioner(jacobi.MultigridPreconditioner{})}, jacobi.ErrUnsupportedMultigridProblem},
+	}
+
+	for _, tc := range testCases {
+		opts := append([]jacobi.Option{jacobi.WithMethod(jacobi.ConjugateGradientMethod)}, tc.opts...)
+		if _, err := jacobi.NewSolver(opts...).Solve(tc.problem); err != tc.expected {
+			t.Errorf("Expected error '%v', got '%v'", tc.expected, err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"testing"
+)
+
+func TestSolveSinglePrecision(t *testing.T) {
+	nDim := 16
+	problem := jacobi.NewProblem(0.5, nDim)
+	problem.Source = matrix.NewOneDimMatrix(0.01, nDim+2, 0.0, 0.0, 0.0, 0.0)
+	problem.Conditions.Right = jacobi.BoundaryCondition{Kind: jacobi.Robin, HeatTransferCoefficient: 0.5}
+
+	for _, matrixType := range []matrix.MatrixType{matrix.OneDimMatrixType, matrix.TwoDimDividedMatrixType, matrix.TwoDimContiguousMatrixType} {
+		var singleMat matrix.MatrixOf[float32]
+		for _, nThreads := range []int{1, 4, 16} {
+			solver := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMatrixType(matrixType))
+
+			res, err := jacobi.SolveOf[float32](solver, problem)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			expected, err := solver.Solve(problem)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if singleMat == nil {
+				singleMat = res.Matrix
+			} else if !matrix.CompareMatrices(singleMat, res.Matrix) {
+				t.Errorf("Expected matrix with num threads=%d and matrix type=%s to match single-threaded one", nThreads, matrixType.ToString())
+			}
+
+			// Both precisions take the same path to the solution, apart from the rounding errors
+			if res.Iterations != expected.Iterations {
+				t.Errorf("Expected %d iterations with num threads=%d and matrix type=%s, got %d", expected.Iterations, nThreads, matrixType.ToString(), res.Iterations)
+			}
+			for i := 0; i < nDim+2; i++ {
+				for j := 0; j < nDim+2; j++ {
+					if actual := float64(res.Matrix.GetCell(i, j)); !utils.CompareFloats(actual, expected.Matrix.GetCell(i, j), 1.0e-5) {
+						t.Fatalf("Expected %.6f in cell (%d, %d) with num threads=%d and matrix type=%s, got %.6f", expected.Matrix.GetCell(i, j), i, j, nThreads, matrixType.ToString(), actual)
+					}
+				}
+			}
+		}
+	}
+}
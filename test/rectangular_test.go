@@ -0,0 +1,128 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"testing"
+)
+
+func TestSolveRectangular(t *testing.T) {
+	rows, cols := 16, 48
+	problem := jacobi.NewRectangularProblem(0.5, rows, cols)
+
+	for _, matrixType := range []matrix.MatrixType{matrix.OneDimMatrixType, matrix.TwoDimDividedMatrixType, matrix.TwoDimContiguousMatrixType} {
+		var singleMat matrix.Matrix
+		for _, nThreads := range []int{1, 4, 16} {
+			res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMatrixType(matrixType)).Solve(problem)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if res.Matrix.GetRows() != rows+2 || res.Matrix.GetCols() != cols+2 {
+				t.Fatalf("Expected %dx%d matrix with num threads=%d, got %dx%d", rows+2, cols+2, nThreads, res.Matrix.GetRows(), res.Matrix.GetCols())
+			}
+			if singleMat == nil {
+				singleMat = res.Matrix
+			} else if !matrix.CompareMatrices(singleMat, res.Matrix) {
+				t.Errorf("Expected matrix with num threads=%d and matrix type=%s to match single-threaded one", nThreads, matrixType.ToString())
+			}
+		}
+	}
+}
+
+func TestSolveRectangularLinear(t *testing.T) {
+	insulated := jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+
+	// Heat flows along the longest side of a plate, whose insulated sides keep the solution linear along it
+	wide := jacobi.NewRectangularProblem(0.5, 4, 32)
+	wide.Boundaries = jacobi.Boundaries{
+		Top:    matrix.ConstantBoundary(0.0),
+		Bottom: matrix.ConstantBoundary(0.0),
+		Left:   matrix.ConstantBoundary(matrix.Hot),
+		Right:  matrix.ConstantBoundary(matrix.Cold),
+	}
+	wide.Conditions = jacobi.BoundaryConditions{Top: insulated, Bottom: insulated}
+
+	// Same plate, rotated
+	tall := jacobi.NewRectangularProblem(0.5, 32, 4)
+	tall.Boundaries = jacobi.Boundaries{
+		Top:    matrix.ConstantBoundary(matrix.Hot),
+		Bottom: matrix.ConstantBoundary(matrix.Cold),
+		Left:   matrix.ConstantBoundary(0.0),
+		Right:  matrix.ConstantBoundary(0.0),
+	}
+	tall.Conditions = jacobi.BoundaryConditions{Left: insulated, Right: insulated}
+
+	for _, nThreads := range []int{1, 4} {
+		solver := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(100000), jacobi.WithTolerance(1.0e-12))
+
+		wideRes, err := solver.Solve(wide)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		tallRes, err := solver.Solve(tall)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for i := 1; i <= 4; i++ {
+			for j := 1; j <= 32; j++ {
+				expected := matrix.Hot - (matrix.Hot-matrix.Cold)*float64(j)/33
+				if actual := wideRes.Matrix.GetCell(i, j); !utils.CompareFloats(actual, expected, 1.0e-6) {
+					t.Fatalf("Expected %.6f in cell (%d, %d) of the wide plate with num threads=%d, got %.6f", expected, i, j, nThreads, actual)
+				}
+				if actual := tallRes.Matrix.GetCell(j, i); !utils.CompareFloats(actual, expected, 1.0e-6) {
+					t.Fatalf("Expected %.6f in cell (%d, %d) of the tall plate with num threads=%d, got %.6f", expected, j, i, nThreads, actual)
+				}
+			}
+		}
+	}
+}
+
+func TestSolveInvalidRectangular(t *testing.T) {
+	rows, cols := 16, 18
+
+	missingCols := jacobi.NewRectangularProblem(0.5, rows, 0)
+
+	// Top and bottom edges are as long as the columns, not the rows
+	shortTop := jacobi.NewRectangularProblem(0.5, rows, cols)
+	shortTop.Boundaries.Top = make(matrix.ValuesBoundary, rows+2)
+
+	squareSource := jacobi.NewRectangularProblem(0.5, rows, cols)
+	squareSource.Source = matrix.NewOneDimMatrix(0.0, rows+2, 0.0, 0.0, 0.0, 0.0)
+
+	squareMask := jacobi.NewRectangularProblem(0.5, rows, cols)
+	squareMask.Mask = jacobi.NewMask(rows)
+
+	testCases := []struct {
+		problem  jacobi.Problem
+		nThreads int
+		expected error
+	}{
+		{missingCols, 1, jacobi.ErrNonPositiveSize},
+		{shortTop, 1, jacobi.ErrBoundaryLength},
+		{squareSource, 1, jacobi.ErrSourceSize},
+		{squareMask, 1, jacobi.ErrMaskSize},
+		{jacobi.NewRectangularProblem(0.5, rows, cols), 4, jacobi.ErrSizeNotDivisible},
+	}
+
+	for _, testCase := range testCases {
+		if _, err := jacobi.NewSolver(jacobi.WithThreads(testCase.nThreads)).Solve(testCase.problem); err != testCase.expected {
+			t.Errorf("Expected error '%v', got '%v'", testCase.expected, err)
+		}
+	}
+}
+
+func TestCompareRectangularMatrices(t *testing.T) {
+	wide := matrix.NewRectangularOneDimMatrix(0.5, 4, 6, matrix.ConstantBoundary(matrix.Hot), matrix.ConstantBoundary(matrix.Cold), matrix.ConstantBoundary(matrix.Hot), matrix.ConstantBoundary(matrix.Hot))
+	tall := matrix.NewRectangularOneDimMatrix(0.5, 6, 4, matrix.ConstantBoundary(matrix.Hot), matrix.ConstantBoundary(matrix.Cold), matrix.ConstantBoundary(matrix.Hot), matrix.ConstantBoundary(matrix.Hot))
+	twoDimWide := matrix.NewRectangularTwoDimMatrix(0.5, 4, 6, matrix.ConstantBoundary(matrix.Hot), matrix.ConstantBoundary(matrix.Cold), matrix.ConstantBoundary(matrix.Hot), matrix.ConstantBoundary(matrix.Hot), matrix.TwoDimContiguousMatrixType)
+
+	if matrix.CompareMatrices(wide, tall) || matrix.CompareMatrices(tall, wide) {
+		t.Errorf("Expected matrices with different shapes not to match")
+	}
+	if !matrix.CompareMatrices(wide, twoDimWide) {
+		t.Errorf("Expected matrices with the same shape and cells to match")
+	}
+}
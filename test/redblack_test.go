@@ -0,0 +1,73 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"testing"
+)
+
+func TestSolveRedBlackGaussSeidel(t *testing.T) {
+	nDim, tolerance := 16, 1.0e-10
+	problem := jacobi.NewProblem(0.5, nDim)
+	problem.Source = matrix.NewOneDimMatrix(0.01, nDim+2, 0.0, 0.0, 0.0, 0.0)
+	problem.Conditions.Right = jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+	problem.Boundaries.Right = matrix.ConstantBoundary(0.0)
+	problem.Conditions.Bottom = jacobi.BoundaryCondition{Kind: jacobi.Robin, HeatTransferCoefficient: 0.5}
+	problem.Mask = jacobi.NewMask(nDim)
+	problem.Mask[5][5] = jacobi.Cell{Kind: jacobi.FixedCell, Value: 2.0}
+	problem.Mask[10][12] = jacobi.Cell{Kind: jacobi.VoidCell}
+
+	expected, err := jacobi.NewSolver(jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance), jacobi.WithMethod(jacobi.GaussSeidelMethod)).Solve(problem)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var singleRes jacobi.Result
+	for _, nThreads := range []int{1, 4, 16} {
+		solver := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance), jacobi.WithMethod(jacobi.RedBlackGaussSeidelMethod))
+		res, err := solver.Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		// Cells of the same color don't depend on each other, so the ordering of the updates doesn't matter
+		if singleRes.Matrix == nil {
+			singleRes = res
+		} else if !matrix.CompareMatrices(singleRes.Matrix, res.Matrix) || singleRes.Iterations != res.Iterations {
+			t.Errorf("Expected matrix and %d iterations with num threads=%d to match single-threaded ones, got %d iterations", singleRes.Iterations, nThreads, res.Iterations)
+		}
+
+		if res.MaxDiff > tolerance {
+			t.Errorf("Expected max diff under %g with num threads=%d, got %g", tolerance, nThreads, res.MaxDiff)
+		}
+		for i := 0; i < nDim+2; i++ {
+			for j := 0; j < nDim+2; j++ {
+				if actual := res.Matrix.GetCell(i, j); !utils.CompareFloats(actual, expected.Matrix.GetCell(i, j), 1.0e-7) {
+					t.Fatalf("Expected %.10f in cell (%d, %d) with num threads=%d, got %.10f", expected.Matrix.GetCell(i, j), i, j, nThreads, actual)
+				}
+			}
+		}
+	}
+}
+
+func TestSolveRedBlackGaussSeidelPeriodic(t *testing.T) {
+	nDim := 16
+	problem := jacobi.NewProblem(0.5, nDim)
+	problem.Conditions.Left = jacobi.BoundaryCondition{Kind: jacobi.Periodic}
+	problem.Conditions.Right = jacobi.BoundaryCondition{Kind: jacobi.Periodic}
+
+	var singleMat matrix.Matrix
+	for _, nThreads := range []int{1, 4, 16} {
+		res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMethod(jacobi.RedBlackGaussSeidelMethod)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if singleMat == nil {
+			singleMat = res.Matrix
+		} else if !matrix.CompareMatrices(singleMat, res.Matrix) {
+			t.Errorf("Expected matrix with num threads=%d to match single-threaded one", nThreads)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"testing"
+)
+
+func TestSolveSOR(t *testing.T) {
+	nDim, tolerance := 32, 1.0e-10
+	problem := jacobi.NewProblem(0.5, nDim)
+	problem.Source = matrix.NewOneDimMatrix(0.01, nDim+2, 0.0, 0.0, 0.0, 0.0)
+
+	newSolver := func(method jacobi.Method, opts ...jacobi.Option) *jacobi.Solver {
+		return jacobi.NewSolver(append([]jacobi.Option{jacobi.WithMaxIters(100000), jacobi.WithTolerance(tolerance), jacobi.WithMethod(method)}, opts...)...)
+	}
+	gaussSeidel, err := newSolver(jacobi.GaussSeidelMethod).Solve(problem)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gaussSeidel.RelaxationFactor != 1.0 {
+		t.Errorf("Expected relaxation factor 1.0 for Gauss-Seidel, got %g", gaussSeidel.RelaxationFactor)
+	}
+
+	// A relaxation factor of 1.0 is the Gauss-Seidel method
+	res, err := newSolver(jacobi.SORMethod, jacobi.WithRelaxationFactor(1.0)).Solve(problem)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !matrix.CompareMatrices(res.Matrix, gaussSeidel.Matrix) || res.Iterations != gaussSeidel.Iterations {
+		t.Errorf("Expected matrix and %d iterations of Gauss-Seidel, got %d iterations", gaussSeidel.Iterations, res.Iterations)
+	}
+
+	// The optimal relaxation factor converges an order of magnitude faster, to the same field
+	for _, method := range []jacobi.Method{jacobi.SORMethod, jacobi.RedBlackSORMethod} {
+		res, err := newSolver(method).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if res.RelaxationFactor <= 1.0 || res.RelaxationFactor >= 2.5 {
+			t.Errorf("Expected optimal relaxation factor between 1.0 and 2.5 with method=%s, got %g", method.ToString(), res.RelaxationFactor)
+		}
+		if res.Iterations > gaussSeidel.Iterations/10 {
+			t.Errorf("Expected fewer than %d iterations with method=%s, got %d", gaussSeidel.Iterations/10, method.ToString(), res.Iterations)
+		}
+		for i := 0; i < nDim+2; i++ {
+			for j := 0; j < nDim+2; j++ {
+				if actual := res.Matrix.GetCell(i, j); !utils.CompareFloats(actual, gaussSeidel.Matrix.GetCell(i, j), 1.0e-7) {
+					t.Fatalf("Expected %.10f in cell (%d, %d) with method=%s, got %.10f", gaussSeidel.Matrix.GetCell(i, j), i, j, method.ToString(), actual)
+				}
+			}
+		}
+	}
+}
+
+func TestSolveRedBlackSOR(t *testing.T) {
+	nDim, omega := 16, 1.7
+	problem := jacobi.NewProblem(0.5, nDim)
+	problem.Conditions.Right = jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+	problem.Boundaries.Right = matrix.ConstantBoundary(0.0)
+
+	var singleRes jacobi.Result
+	for _, nThreads := range []int{1, 4, 16} {
+		res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMethod(jacobi.RedBlackSORMethod), jacobi.WithRelaxationFactor(omega)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if res.RelaxationFactor != omega {
+			t.Errorf("Expected relaxation factor %g with num threads=%d, got %g", omega, nThreads, res.RelaxationFactor)
+		}
+		if singleRes.Matrix == nil {
+			singleRes = res
+		} else if !matrix.CompareMatrices(singleRes.Matrix, res.Matrix) || singleRes.Iterations != res.Iterations {
+			t.Errorf("Expected matrix and %d iterations with num threads=%d to match single-threaded ones, got %d iterations", singleRes.Iterations, nThreads, res.Iterations)
+		}
+	}
+}
+
+func TestSolveInvalidSOR(t *testing.T) {
+	testCases := []struct {
+		opts        []jacobi.Option
+		expectedErr error
+	}{
+		{[]jacobi.Option{jacobi.WithMethod(jacobi.SORMethod), jacobi.WithRelaxationFactor(-1.0)}, jacobi.ErrRelaxationFactorOutOfRange},
+		{[]jacobi.Option{jacobi.WithMethod(jacobi.RedBlackSORMethod), jacobi.WithRelaxationFactor(2.5)}, jacobi.ErrRelaxationFactorOutOfRange},
+		{[]jacobi.Option{jacobi.WithMethod(jacobi.SORMethod), jacobi.WithThreads(4)}, jacobi.ErrSequentialMethod},
+	}
+
+	for _, testCase := range testCases {
+		if _, err := jacobi.NewSolver(testCase.opts...).Solve(jacobi.NewProblem(0.5, 16)); err != testCase.expectedErr {
+			t.Errorf("Expected error '%v', got '%v'", testCase.expectedErr, err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"github.com/mcanalesmayo/jacobi-go"
+	"github.com/mcanalesmayo/jacobi-go/model/matrix"
+	"github.com/mcanalesmayo/jacobi-go/utils"
+	"testing"
+)
+
+func TestSolveSource(t *testing.T) {
+	nDim := 16
+	spacing := 1.0 / float64(nDim+1)
+	insulated := jacobi.BoundaryCondition{Kind: jacobi.Neumann}
+	cold := matrix.ConstantBoundary(matrix.Cold)
+
+	// Uniform heat source between two cold edges
+	problem := jacobi.Problem{
+		InitialValue: 0.0,
+		NDim:         nDim,
+		Boundaries:   jacobi.Boundaries{Top: cold, Bottom: cold, Left: cold, Right: cold},
+		Conditions:   jacobi.BoundaryConditions{Left: insulated, Right: insulated},
+		Spacing:      spacing,
+		Source:       matrix.NewOneDimMatrix(1.0, nDim+2, 1.0, 1.0, 1.0, 1.0),
+	}
+
+	// 1D analytical solution of -u''=1 with u(0)=u(1)=0
+	expected := func(i int) float64 {
+		x := float64(i) * spacing
+		return x * (1 - x) / 2
+	}
+
+	for _, nThreads := range []int{1, 4} {
+		res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(100000), jacobi.WithTolerance(1.0e-14)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for i := 1; i <= nDim; i++ {
+			for j := 1; j <= nDim; j++ {
+				if actual := res.Matrix.GetCell(i, j); !utils.CompareFloats(actual, expected(i), 1.0e-8) {
+					t.Fatalf("Expected %.8f in cell (%d, %d) with num threads=%d, got %.8f", expected(i), i, j, nThreads, actual)
+				}
+			}
+		}
+	}
+}
+
+func TestSolveSourceDecomposition(t *testing.T) {
+	nDim := 16
+	problem := jacobi.NewProblem(0.5, nDim)
+
+	// Components dissipating power and a heat sink, some of them straddling workers submatrices
+	problem.Source = matrix.NewTwoDimMatrix(0.0, nDim+2, 0.0, 0.0, 0.0, 0.0, matrix.TwoDimContiguousMatrixType)
+	for i := 6; i <= 11; i++ {
+		problem.Source.SetCell(i, 8, 0.3)
+		problem.Source.SetCell(3, i, 0.1)
+		problem.Source.SetCell(i, 14, -0.2)
+	}
+
+	var singleMat matrix.Matrix
+	for _, nThreads := range []int{1, 4, 16} {
+		res, err := jacobi.NewSolver(jacobi.WithThreads(nThreads), jacobi.WithMaxIters(10000), jacobi.WithTolerance(1.0e-10)).Solve(problem)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if singleMat == nil {
+			singleMat = res.Matrix
+		} else if !matrix.CompareMatrices(singleMat, res.Matrix) {
+			t.Errorf("Expected matrix with num threads=%d to match single-threaded one", nThreads)
+		}
+	}
+
+	problem.Source = matrix.NewOneDimMatrix(0.0, nDim, 0.0, 0.0, 0.0, 0.0)
+	if _, err := jacobi.NewSolver().Solve(problem); err != jacobi.ErrSourceSize {
+		t.Errorf("Expected error '%v', got '%v'", jacobi.ErrSourceSize, err)
+	}
+}
@@ -10,6 +10,14 @@ const (
 	Epsilon = 1.0e-9
 )
 
+// Abs returns the absolute value of a fp number of any precision
+func Abs[T ~float32 | ~float64](fp T) T {
+	if fp < 0 {
+		return -fp
+	}
+	return fp
+}
+
 // CompareFloats compares two fp numbers taking into account that fp representation
 // results in approximations.
 // epsilon is taken as maximum absoluteValue(fpA-fpB).